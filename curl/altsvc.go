@@ -0,0 +1,187 @@
+package curl
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// max age of an alternative service without the ma parameter, RFC 7838
+	defaultAltSvcMaxAge = 24 * time.Hour
+	// how long an origin is sent over TCP after its HTTP/3 connection failed
+	defaultHTTP3BrokenTimeout = 5 * time.Minute
+)
+
+// altSvc is a HTTP/3 alternative service of an origin
+type altSvc struct {
+	port    string
+	expires time.Time
+}
+
+// altSvcCache remembers the origins which advertised h3 in Alt-Svc and the
+// origins whose HTTP/3 connection failed. libcurl is built without its own
+// alt-svc cache, so this is kept on the Go side. The zero value is usable.
+type altSvcCache struct {
+	mu       sync.Mutex
+	services map[string]altSvc
+	broken   map[string]time.Time
+}
+
+// lookup returns the port of the HTTP/3 service of origin
+func (c *altSvcCache) lookup(origin string, now time.Time) (port string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if until, isBroken := c.broken[origin]; isBroken {
+		if now.Before(until) {
+			return "", false
+		}
+		delete(c.broken, origin)
+	}
+
+	service, ok := c.services[origin]
+	if !ok {
+		return "", false
+	}
+	if !now.Before(service.expires) {
+		delete(c.services, origin)
+		return "", false
+	}
+	return service.port, true
+}
+
+// update applies the Alt-Svc header values of a response from origin on
+// host, a response without Alt-Svc keeps what is cached.
+func (c *altSvcCache) update(origin, host string, values []string, now time.Time) {
+	if len(values) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	service, ok := parseAltSvc(values, host, now)
+	if !ok {
+		delete(c.services, origin)
+		return
+	}
+	if c.services == nil {
+		c.services = make(map[string]altSvc)
+	}
+	c.services[origin] = service
+}
+
+// markBroken sends origin over TCP until timeout passes
+func (c *altSvcCache) markBroken(origin string, now time.Time, timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.broken == nil {
+		c.broken = make(map[string]time.Time)
+	}
+	c.broken[origin] = now.Add(timeout)
+}
+
+// altSvcConnectError is the error of an HTTP/3 attempt which failed before
+// its request was sent, while connecting or in the QUIC handshake
+type altSvcConnectError struct {
+	err error
+}
+
+func (e *altSvcConnectError) Error() string {
+	return e.err.Error()
+}
+
+func (e *altSvcConnectError) Unwrap() error {
+	return e.err
+}
+
+// altSvcOrigin returns the cache key of an https URL, "" for other schemes
+func altSvcOrigin(u *url.URL) string {
+	if u.Scheme != "https" {
+		return ""
+	}
+	return "https://" + net.JoinHostPort(strings.ToLower(u.Hostname()), originPort(u))
+}
+
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	if u.Scheme == "http" {
+		return "80"
+	}
+	return "443"
+}
+
+// parseAltSvc returns the first h3 service of Alt-Svc values on the origin
+// host, e.g. `h3=":443"; ma=3600, h3-29=":443"`. Services on other hosts
+// are skipped, ok is false when there is none or the list is "clear".
+func parseAltSvc(values []string, host string, now time.Time) (service altSvc, ok bool) {
+	for _, value := range values {
+		for _, alternative := range splitQuoted(value, ',') {
+			params := splitQuoted(alternative, ';')
+			protocolAuthority := strings.SplitN(strings.TrimSpace(params[0]), "=", 2)
+			protocol := strings.TrimSpace(protocolAuthority[0])
+			if protocol == "clear" {
+				return altSvc{}, false
+			}
+			if len(protocolAuthority) != 2 || (protocol != "h3" && !strings.HasPrefix(protocol, "h3-")) {
+				continue
+			}
+
+			altHost, port, err := net.SplitHostPort(strings.Trim(strings.TrimSpace(protocolAuthority[1]), `"`))
+			if err != nil || port == "" || (altHost != "" && !strings.EqualFold(altHost, host)) {
+				continue
+			}
+
+			maxAge := defaultAltSvcMaxAge
+			for _, param := range params[1:] {
+				keyValue := strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) != "ma" {
+					continue
+				}
+				if seconds, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(keyValue[1]), `"`), 10, 64); err == nil {
+					maxAge = time.Duration(seconds) * time.Second
+				}
+			}
+			if maxAge <= 0 {
+				continue
+			}
+
+			if !ok {
+				service, ok = altSvc{port: port, expires: now.Add(maxAge)}, true
+			}
+		}
+	}
+	return
+}
+
+// splitQuoted splits s by sep outside of double quotes
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// altSvcConnectTo is the OPT_CONNECT_TO entry sending requestURL to port of
+// the host of u, u differs from requestURL when tls.Config.ServerName is set.
+func altSvcConnectTo(requestURL, u *url.URL, port string) string {
+	return connectToHost(requestURL.Hostname()) + ":" + originPort(requestURL) + ":" + connectToHost(u.Hostname()) + ":" + port
+}
@@ -0,0 +1,153 @@
+package curl
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseAltSvc(t *testing.T) {
+	now := time.Now()
+
+	service, ok := parseAltSvc([]string{`h2=":443", h3-29=":8443"; ma=60, h3=":443"`}, "example.com", now)
+	if !ok || service.port != "8443" || !service.expires.Equal(now.Add(time.Minute)) {
+		t.Errorf("service should be port 8443 for 60s and is %v, %v.", service, ok)
+	}
+
+	service, ok = parseAltSvc([]string{`h3="other.com:443", h3="EXAMPLE.com:444"; persist=1`}, "example.com", now)
+	if !ok || service.port != "444" || !service.expires.Equal(now.Add(defaultAltSvcMaxAge)) {
+		t.Errorf("service should be port 444 for a day and is %v, %v.", service, ok)
+	}
+
+	for _, value := range []string{"clear", `h2=":443"`, `h3=":443"; ma=0`, `h3="other.com:443"`} {
+		if service, ok = parseAltSvc([]string{value}, "example.com", now); ok {
+			t.Errorf("%q should have no service and has %v.", value, service)
+		}
+	}
+}
+
+func TestAltSvcCache(t *testing.T) {
+	u, _ := url.Parse("https://Example.com/path")
+	origin := altSvcOrigin(u)
+	if origin != "https://example.com:443" {
+		t.Fatalf("origin should be https://example.com:443 and is %q.", origin)
+	}
+
+	var cache altSvcCache
+	now := time.Now()
+	if _, ok := cache.lookup(origin, now); ok {
+		t.Error("empty cache should have no service.")
+	}
+
+	cache.update(origin, u.Hostname(), []string{`h3=":443"; ma=60`}, now)
+	if port, ok := cache.lookup(origin, now); !ok || port != "443" {
+		t.Errorf("service should be port 443 and is %q, %v.", port, ok)
+	}
+	cache.update(origin, u.Hostname(), nil, now)
+	if _, ok := cache.lookup(origin, now); !ok {
+		t.Error("response without Alt-Svc should keep the service.")
+	}
+	if _, ok := cache.lookup(origin, now.Add(time.Minute)); ok {
+		t.Error("service should expire.")
+	}
+
+	cache.update(origin, u.Hostname(), []string{`h3=":443"`}, now)
+	cache.markBroken(origin, now, time.Minute)
+	if _, ok := cache.lookup(origin, now.Add(time.Second)); ok {
+		t.Error("broken origin should have no service.")
+	}
+	if _, ok := cache.lookup(origin, now.Add(time.Minute)); !ok {
+		t.Error("service should come back after the broken timeout.")
+	}
+
+	cache.update(origin, u.Hostname(), []string{"clear"}, now)
+	if _, ok := cache.lookup(origin, now); ok {
+		t.Error("clear should remove the service.")
+	}
+}
+
+func TestAltSvcFallback(t *testing.T) {
+	// nothing answers HTTP/3 on the advertised port
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, h3Port, _ := net.SplitHostPort(udp.LocalAddr().String())
+	udp.Close()
+
+	var requests int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Alt-Svc", `h3=":`+h3Port+`"`)
+		w.Write([]byte(r.Proto))
+	}))
+	defer ts.Close()
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	pool, err := NewCertPool(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ReleaseCertPool(pool)
+
+	transport := &Transport{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		Protocol:  ProtocolHTTP3Upgrade,
+	}
+	client := &http.Client{Transport: transport}
+	u, _ := url.Parse(ts.URL)
+	origin := altSvcOrigin(u)
+
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		proto, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if string(proto) != "HTTP/1.1" {
+			t.Errorf("request %d should be sent with Transport and is %s.", i, proto)
+		}
+
+		_, ok := transport.altSvc.lookup(origin, time.Now())
+		if i == 0 && !ok {
+			t.Fatal("Alt-Svc of the first response should be cached.")
+		}
+		if i == 1 && ok {
+			t.Error("origin should be broken after the HTTP/3 connection failed.")
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("server should get 2 requests and gets %d.", n)
+	}
+}
+
+func TestIsReplayable(t *testing.T) {
+	for _, test := range []struct {
+		method     string
+		getBody    bool
+		replayable bool
+	}{
+		{method: http.MethodGet, replayable: true},
+		{method: http.MethodPut, replayable: true},
+		{method: http.MethodPost},
+		{method: http.MethodPost, getBody: true, replayable: true},
+		{method: http.MethodPatch},
+	} {
+		request, _ := http.NewRequest(test.method, "https://example.com", nil)
+		if test.getBody {
+			request.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		}
+		if replayable := isReplayable(request); replayable != test.replayable {
+			t.Errorf("%s with GetBody %v: replayable should be %v and is %v.", test.method, test.getBody, test.replayable, replayable)
+		}
+	}
+}
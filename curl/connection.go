@@ -0,0 +1,106 @@
+package curl
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// like net.Dialer, keep-alive probes are sent every 15 seconds if KeepAlive is zero
+const defaultKeepAlive = 15 * time.Second
+
+// connConfig is how libcurl opens and keeps the connections of a transfer,
+// it follows the net.Dialer and the http.Transport of the Transport.
+type connConfig struct {
+	// net.Dialer.KeepAlive, negative disables keep-alive probes
+	KeepAlive time.Duration
+	// net.Dialer.LocalAddr, a *net.TCPAddr
+	LocalAddr net.Addr
+	// net.Dialer.FallbackDelay, the happy eyeballs timeout of libcurl
+	FallbackDelay time.Duration
+	// http.Transport.IdleConnTimeout, zero keeps the default of libcurl
+	IdleConnTimeout time.Duration
+	// http.Transport.DisableKeepAlives, a connection is closed after its transfer
+	DisableKeepAlives bool
+}
+
+func newConnConfig(dialer *net.Dialer, transport *http.Transport) connConfig {
+	return connConfig{
+		KeepAlive:         dialer.KeepAlive,
+		LocalAddr:         dialer.LocalAddr,
+		FallbackDelay:     dialer.FallbackDelay,
+		IdleConnTimeout:   transport.IdleConnTimeout,
+		DisableKeepAlives: transport.DisableKeepAlives,
+	}
+}
+
+func setupConnection(easy *libcurl.CURL, config *connConfig) (err error) {
+	if config.KeepAlive >= 0 {
+		keepAlive := config.KeepAlive
+		if keepAlive == 0 {
+			keepAlive = defaultKeepAlive
+		}
+		err = easy.SetTCPKeepAlive(true)
+		if err != nil {
+			return
+		}
+
+		// libcurl counts in seconds, the setters round up
+		err = easy.SetTCPKeepIdle(keepAlive)
+		if err != nil {
+			return
+		}
+
+		err = easy.SetTCPKeepIntvl(keepAlive)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.LocalAddr != nil {
+		addr, ok := config.LocalAddr.(*net.TCPAddr)
+		if !ok {
+			return fmt.Errorf("curl: unsupported local address %s", config.LocalAddr)
+		}
+
+		// the host! prefix binds to an address instead of an interface
+		if addr.IP != nil {
+			err = easy.Setopt(libcurl.OPT_INTERFACE, "host!"+addr.IP.String())
+			if err != nil {
+				return
+			}
+		}
+
+		if addr.Port != 0 {
+			err = easy.Setopt(libcurl.OPT_LOCALPORT, addr.Port)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if config.FallbackDelay > 0 {
+		err = easy.Setopt(libcurl.OPT_HAPPY_EYEBALLS_TIMEOUT_MS, int64(config.FallbackDelay/time.Millisecond))
+		if err != nil {
+			return
+		}
+	}
+
+	if config.IdleConnTimeout > 0 {
+		err = easy.SetMaxAgeConn(config.IdleConnTimeout)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.DisableKeepAlives {
+		err = easy.Setopt(libcurl.OPT_FORBID_REUSE, 1)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,218 @@
+package curl
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// netscape cookie lines mark HttpOnly cookies with this domain prefix
+const httpOnlyPrefix = "#HttpOnly_"
+
+var errCookiesDisabled = errors.New("curl: libcurl is built without cookie support")
+
+// cookieBridge syncs a http.CookieJar with the cookie engine of a transfer.
+// When libcurl is built without cookies the jar is synced with the Cookie
+// and Set-Cookie headers instead.
+type cookieBridge struct {
+	jar http.CookieJar
+	u   *url.URL
+	// libcurl has no cookie engine
+	headerOnly bool
+	// the cookies the engine holds before and after the transfer
+	loaded, lines []string
+}
+
+// setupCookies enables the cookie engine of the transfer and loads the
+// cookies jar has for u, the bridge is nil without jar. Like http.Transport
+// the jar is keyed on the URL of the request, the engine gets the cookies
+// for transferURL, the URL libcurl requests. cookieFile is read before and
+// written after the transfer by libcurl.
+func setupCookies(easy *libcurl.CURL, u, transferURL *url.URL, jar http.CookieJar, cookieFile string) (bridge *cookieBridge, err error) {
+	if cookieFile != "" {
+		err = easy.Setopt(libcurl.OPT_COOKIEFILE, cookieFile)
+		if err == libcurl.CurlError(libcurl.E_UNKNOWN_OPTION) {
+			err = errCookiesDisabled
+		}
+		if err != nil {
+			return
+		}
+
+		err = easy.Setopt(libcurl.OPT_COOKIEJAR, cookieFile)
+		if err != nil {
+			return
+		}
+	}
+
+	if jar == nil {
+		return
+	}
+
+	bridge = &cookieBridge{jar: jar, u: u}
+	if cookieFile == "" {
+		// an empty file name enables the engine without reading a file
+		err = easy.Setopt(libcurl.OPT_COOKIEFILE, "")
+		if err == libcurl.CurlError(libcurl.E_UNKNOWN_OPTION) {
+			bridge.headerOnly = true
+			return bridge, nil
+		}
+	} else {
+		// libcurl reads the file when the transfer starts, load it now so
+		// its cookies are not taken for cookies the response set
+		err = easy.Setopt(libcurl.OPT_COOKIELIST, "RELOAD")
+	}
+	if err != nil {
+		return
+	}
+
+	for _, cookie := range jar.Cookies(u) {
+		err = easy.Setopt(libcurl.OPT_COOKIELIST, jarCookieLine(transferURL, cookie))
+		if err != nil {
+			return
+		}
+	}
+
+	bridge.loaded, err = cookieList(easy)
+	return
+}
+
+// requestHeader returns header with the cookies of the jar when libcurl
+// can not send them.
+func (b *cookieBridge) requestHeader(header http.Header) http.Header {
+	if !b.headerOnly {
+		return header
+	}
+
+	cookies := b.jar.Cookies(b.u)
+	if len(cookies) == 0 {
+		return header
+	}
+
+	// like http.Request.AddCookie
+	values := make([]string, 0, len(cookies)+1)
+	if value := header.Get("Cookie"); value != "" {
+		values = append(values, value)
+	}
+	for _, cookie := range cookies {
+		values = append(values, (&http.Cookie{Name: cookie.Name, Value: cookie.Value}).String())
+	}
+	header = header.Clone()
+	header.Set("Cookie", strings.Join(values, "; "))
+	return header
+}
+
+// collect reads the cookies of the engine, it is called once the header of
+// the final response is received.
+func (b *cookieBridge) collect(easy *libcurl.CURL) {
+	if !b.headerOnly {
+		b.lines, _ = cookieList(easy)
+	}
+}
+
+// sync stores the cookies of the response in the jar
+func (b *cookieBridge) sync(header http.Header) {
+	if !b.headerOnly {
+		syncCookies(b.jar, b.u, b.loaded, b.lines)
+		return
+	}
+
+	if cookies := (&http.Response{Header: header}).Cookies(); len(cookies) > 0 {
+		b.jar.SetCookies(b.u, cookies)
+	}
+}
+
+// cookieList returns the cookies of the engine as netscape cookie lines
+func cookieList(easy *libcurl.CURL) ([]string, error) {
+	linesI, err := easy.Getinfo(libcurl.INFO_COOKIELIST)
+	if err != nil {
+		return nil, err
+	}
+	lines, _ := linesI.([]string)
+	return lines, nil
+}
+
+// syncCookies stores the cookies the transfer set or removed in jar
+func syncCookies(jar http.CookieJar, u *url.URL, loaded, lines []string) {
+	before := make(map[string]bool, len(loaded))
+	for _, line := range loaded {
+		before[line] = true
+	}
+
+	present := make(map[string]bool, len(lines))
+	var cookies []*http.Cookie
+	for _, line := range lines {
+		cookie := parseCookieLine(line)
+		if cookie == nil {
+			continue
+		}
+		present[cookieKey(line)] = true
+		if !before[line] {
+			cookies = append(cookies, cookie)
+		}
+	}
+
+	// a cookie the response expired is gone from the engine
+	for _, line := range loaded {
+		if cookie := parseCookieLine(line); cookie != nil && !present[cookieKey(line)] {
+			cookies = append(cookies, &http.Cookie{Name: cookie.Name, Domain: cookie.Domain, Path: cookie.Path, MaxAge: -1})
+		}
+	}
+
+	if len(cookies) > 0 {
+		jar.SetCookies(u, cookies)
+	}
+}
+
+// cookieKey identifies the cookie of a netscape cookie line by its domain,
+// path and name, like the engine does
+func cookieKey(line string) string {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 7 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[0], httpOnlyPrefix) + "\t" + fields[2] + "\t" + fields[5]
+}
+
+// jarCookieLine formats a cookie of jar as a netscape cookie line for u,
+// jar only returns name and value so it is a host-only session cookie.
+func jarCookieLine(u *url.URL, cookie *http.Cookie) string {
+	return strings.Join([]string{
+		u.Hostname(), "FALSE", "/", "FALSE", "0", cookie.Name, cookie.Value,
+	}, "\t")
+}
+
+// parseCookieLine parses a netscape cookie line of INFO_COOKIELIST:
+// domain, tailmatch, path, secure, expires, name and value separated by tabs.
+func parseCookieLine(line string) *http.Cookie {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 7 {
+		return nil
+	}
+
+	cookie := &http.Cookie{
+		Path:   fields[2],
+		Secure: fields[3] == "TRUE",
+		Name:   fields[5],
+		Value:  fields[6],
+	}
+
+	domain := fields[0]
+	if strings.HasPrefix(domain, httpOnlyPrefix) {
+		domain = strings.TrimPrefix(domain, httpOnlyPrefix)
+		cookie.HttpOnly = true
+	}
+	// a cookie without tailmatch is host-only, the jar takes that from an empty domain
+	if fields[1] == "TRUE" {
+		cookie.Domain = strings.TrimPrefix(domain, ".")
+	}
+
+	if expires, err := strconv.ParseInt(fields[4], 10, 64); err == nil && expires > 0 {
+		cookie.Expires = time.Unix(expires, 0)
+	}
+	return cookie
+}
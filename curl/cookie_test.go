@@ -0,0 +1,182 @@
+package curl
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+func TestParseCookieLine(t *testing.T) {
+	cookie := parseCookieLine("#HttpOnly_.example.com\tTRUE\t/path\tTRUE\t1700000000\tname\tvalue")
+	if cookie == nil {
+		t.Fatal("cookie line should be parsed.")
+	}
+	if cookie.Name != "name" || cookie.Value != "value" || cookie.Domain != "example.com" || cookie.Path != "/path" {
+		t.Errorf("cookie should be name=value on example.com/path and is %v.", cookie)
+	}
+	if !cookie.Secure || !cookie.HttpOnly || !cookie.Expires.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("cookie should be secure, http only and expire and is %v.", cookie)
+	}
+
+	cookie = parseCookieLine("example.com\tFALSE\t/\tFALSE\t0\tsession\t")
+	if cookie == nil || cookie.Domain != "" || !cookie.Expires.IsZero() || cookie.Value != "" {
+		t.Errorf("cookie should be a host-only session cookie and is %v.", cookie)
+	}
+
+	if cookie = parseCookieLine("invalid"); cookie != nil {
+		t.Errorf("invalid line should not be parsed and is %v.", cookie)
+	}
+}
+
+func TestSyncCookies(t *testing.T) {
+	u, _ := url.Parse("https://example.com/")
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "kept", Value: "1"}, {Name: "removed", Value: "2"}})
+
+	var loaded []string
+	for _, cookie := range jar.Cookies(u) {
+		loaded = append(loaded, jarCookieLine(u, cookie))
+	}
+
+	expires := time.Now().Add(time.Hour).Unix()
+	syncCookies(jar, u, loaded, []string{
+		jarCookieLine(u, &http.Cookie{Name: "kept", Value: "1"}),
+		"example.com\tFALSE\t/\tTRUE\t" + strconv.FormatInt(expires, 10) + "\tadded\t3",
+	})
+
+	values := map[string]string{}
+	for _, cookie := range jar.Cookies(u) {
+		values[cookie.Name] = cookie.Value
+	}
+	if len(values) != 2 || values["kept"] != "1" || values["added"] != "3" {
+		t.Errorf("jar should have kept=1 and added=3 and has %v.", values)
+	}
+}
+
+func TestSyncCookiesPaths(t *testing.T) {
+	u, _ := url.Parse("https://example.com/")
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "name", Value: "1"}})
+
+	// the engine also has a cookie of the same name on another path
+	other := "example.com\tFALSE\t/other\tFALSE\t0\tname\t2"
+	loaded := []string{jarCookieLine(u, &http.Cookie{Name: "name", Value: "1"}), other}
+
+	syncCookies(jar, u, loaded, []string{other})
+	if cookies := jar.Cookies(u); len(cookies) != 0 {
+		t.Errorf("cookie the response removed from / should be removed and is %v.", cookies)
+	}
+}
+
+func TestSetupCookiesServerName(t *testing.T) {
+	if err := globalInit(); err != nil {
+		t.Fatal(err)
+	}
+	easy := libcurl.EasyInit()
+	defer cleanupEasy(easy)
+
+	// the request URL and the URL of the tls.Config ServerName libcurl requests
+	u, _ := url.Parse("https://origin.test/")
+	transferURL, _ := url.Parse("https://sni.test/")
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "jar", Value: "1"}})
+
+	bridge, err := setupCookies(easy, u, transferURL, jar, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := http.Header{"Set-Cookie": {"added=3"}}
+	if bridge.headerOnly {
+		if cookie := bridge.requestHeader(http.Header{}).Get("Cookie"); cookie != "jar=1" {
+			t.Errorf("request should have the cookies of the jar for the request URL and has %q.", cookie)
+		}
+	} else {
+		line := jarCookieLine(transferURL, &http.Cookie{Name: "jar", Value: "1"})
+		if len(bridge.loaded) != 1 || bridge.loaded[0] != line {
+			t.Errorf("engine should have the cookies of the jar for the requested host and has %q.", bridge.loaded)
+		}
+		bridge.lines = append(bridge.loaded, jarCookieLine(transferURL, &http.Cookie{Name: "added", Value: "3"}))
+	}
+	bridge.sync(header)
+
+	values := map[string]string{}
+	for _, cookie := range jar.Cookies(u) {
+		values[cookie.Name] = cookie.Value
+	}
+	if len(values) != 2 || values["jar"] != "1" || values["added"] != "3" {
+		t.Errorf("jar should have jar=1 and added=3 for the request URL and has %v.", values)
+	}
+	if cookies := jar.Cookies(transferURL); len(cookies) != 0 {
+		t.Errorf("jar should have no cookies for the server name and has %v.", cookies)
+	}
+}
+
+func TestTransportCookieJarAndFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var names []string
+		for _, cookie := range r.Cookies() {
+			names = append(names, cookie.Name+"="+cookie.Value)
+		}
+		sort.Strings(names)
+		http.SetCookie(w, &http.Cookie{Name: "added", Value: "3"})
+		http.SetCookie(w, &http.Cookie{Name: "file", MaxAge: -1})
+		w.Write([]byte(strings.Join(names, "; ")))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	file, err := ioutil.TempFile("", "go-curl-cookies-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(u.Hostname() + "\tFALSE\t/\tFALSE\t0\tfile\t2\n")
+	file.Close()
+
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(u, []*http.Cookie{{Name: "jar", Value: "1"}})
+	client := &http.Client{Transport: &Transport{
+		Transport:  &http.Transport{},
+		Protocol:   ProtocolHTTP1,
+		Jar:        jar,
+		CookieFile: file.Name(),
+	}}
+
+	response, err := client.Get(ts.URL)
+	if errors.Is(err, errCookiesDisabled) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if string(sent) != "file=2; jar=1" {
+		t.Errorf("request should have the cookies of the jar and the file and has %q.", sent)
+	}
+
+	values := map[string]string{}
+	for _, cookie := range jar.Cookies(u) {
+		values[cookie.Name] = cookie.Value
+	}
+	if len(values) != 2 || values["jar"] != "1" || values["added"] != "3" {
+		t.Errorf("jar should have jar=1 and added=3 and has %v.", values)
+	}
+
+	content, _ := ioutil.ReadFile(file.Name())
+	if !strings.Contains(string(content), "\tadded\t3") || strings.Contains(string(content), "\tfile\t") {
+		t.Errorf("cookie file should have added=3 without file and is %q.", content)
+	}
+}
@@ -0,0 +1,177 @@
+package curl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+var errAresDisabled = errors.New("curl: libcurl is built without c-ares, DNS servers can not be set")
+
+// dnsConfig is how libcurl resolves the hosts of a transfer
+type dnsConfig struct {
+	// OPT_DNS_SERVERS, OPT_DNS_INTERFACE, OPT_DNS_LOCAL_IP4 and
+	// OPT_DNS_LOCAL_IP6 need libcurl built with c-ares
+	Servers   []string
+	Interface string
+	LocalIP4  string
+	LocalIP6  string
+	// zero keeps the default of libcurl, negative disables the cache
+	CacheTimeout     time.Duration
+	ShuffleAddresses bool
+	// OPT_IPRESOLVE, one of the libcurl.IPRESOLVE_* constants
+	IPResolve int
+	// pre-resolves a host, the addresses are passed to libcurl with OPT_RESOLVE
+	Resolver func(ctx context.Context, host string) ([]string, error)
+	// OPT_DOH_URL, the DNS-over-HTTPS server hosts are resolved with
+	DoHURL string
+}
+
+// DoHError is the error of a request whose host could not be resolved with
+// DNS-over-HTTPS, the failed queries are not told apart by libcurl.
+type DoHError struct {
+	// the DNS-over-HTTPS server
+	URL  string
+	Host string
+	// the libcurl.CurlError of the transfer
+	Err error
+}
+
+func (e *DoHError) Error() string {
+	return "curl: resolve " + e.Host + " with DNS-over-HTTPS server " + e.URL + ": " + e.Err.Error()
+}
+
+func (e *DoHError) Unwrap() error {
+	return e.Err
+}
+
+// dohError wraps a failure to resolve host with the DoH server of config
+func dohError(config *dnsConfig, host string, err error) error {
+	if config.DoHURL == "" || err != libcurl.CurlError(libcurl.E_COULDNT_RESOLVE_HOST) {
+		return err
+	}
+	return &DoHError{URL: config.DoHURL, Host: host, Err: err}
+}
+
+// setupDNS applies config and the OPT_RESOLVE entries of resolve to the
+// transfer. host and port are the address the transfer connects to, the
+// Resolver resolves them; empty when libcurl does not resolve the host.
+func setupDNS(ctx context.Context, easy *libcurl.CURL, config *dnsConfig, resolve []string, host, port string) (err error) {
+	err = setupAres(easy, config)
+	if err != nil {
+		return
+	}
+
+	if config.CacheTimeout != 0 {
+		// libcurl caches for seconds, 0 disables the cache
+		timeout := int64(config.CacheTimeout / time.Second)
+		if config.CacheTimeout < 0 {
+			timeout = 0
+		} else if timeout == 0 {
+			timeout = 1
+		}
+		err = easy.Setopt(libcurl.OPT_DNS_CACHE_TIMEOUT, timeout)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.ShuffleAddresses {
+		err = easy.Setopt(libcurl.OPT_DNS_SHUFFLE_ADDRESSES, 1)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.DoHURL != "" {
+		err = easy.Setopt(libcurl.OPT_DOH_URL, config.DoHURL)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.IPResolve != libcurl.IPRESOLVE_WHATEVER {
+		err = easy.Setopt(libcurl.OPT_IPRESOLVE, config.IPResolve)
+		if err != nil {
+			return
+		}
+	}
+
+	// the addresses of the Resolver come before the static entries, libcurl
+	// uses the last entry of a host
+	entries := resolve
+	if config.Resolver != nil && host != "" && net.ParseIP(host) == nil {
+		var addrs []string
+		addrs, err = config.Resolver(ctx, host)
+		if err != nil {
+			return
+		}
+		if entry := resolveEntry(host, port, addrs); entry != "" {
+			entries = append([]string{entry}, resolve...)
+		}
+	}
+
+	if len(entries) > 0 {
+		err = easy.Setopt(libcurl.OPT_RESOLVE, entries)
+	}
+	return
+}
+
+// setupAres applies the options of c-ares, libcurl without it does not know them
+func setupAres(easy *libcurl.CURL, config *dnsConfig) (err error) {
+	defer func() {
+		if err == libcurl.CurlError(libcurl.E_UNKNOWN_OPTION) || err == libcurl.CurlError(libcurl.E_NOT_BUILT_IN) {
+			err = errAresDisabled
+		}
+	}()
+
+	if len(config.Servers) > 0 {
+		err = easy.Setopt(libcurl.OPT_DNS_SERVERS, strings.Join(config.Servers, ","))
+		if err != nil {
+			return
+		}
+	}
+
+	if config.Interface != "" {
+		err = easy.Setopt(libcurl.OPT_DNS_INTERFACE, config.Interface)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.LocalIP4 != "" {
+		err = easy.Setopt(libcurl.OPT_DNS_LOCAL_IP4, config.LocalIP4)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.LocalIP6 != "" {
+		err = easy.Setopt(libcurl.OPT_DNS_LOCAL_IP6, config.LocalIP6)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// resolveEntry formats an OPT_RESOLVE entry, IPv6 addresses are bracketed
+func resolveEntry(host, port string, addrs []string) string {
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	formatted := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+			addr = "[" + addr + "]"
+		}
+		formatted = append(formatted, addr)
+	}
+	return host + ":" + port + ":" + strings.Join(formatted, ",")
+}
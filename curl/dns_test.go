@@ -0,0 +1,63 @@
+package curl
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTransportResolve(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer ts.Close()
+
+	serverURL, _ := url.Parse(ts.URL)
+	port := serverURL.Port()
+
+	var resolved []string
+	transports := map[string]*Transport{
+		"http://resolve.test:" + port: {Resolve: []string{"resolve.test:" + port + ":127.0.0.1"}},
+		"http://connect-to.test":      {ConnectTo: []string{"connect-to.test:80:127.0.0.1:" + port}},
+		"http://resolver.test:" + port: {Resolver: func(ctx context.Context, host string) ([]string, error) {
+			resolved = append(resolved, host)
+			return []string{"127.0.0.1"}, nil
+		}},
+	}
+
+	for u, transport := range transports {
+		transport.Transport = &http.Transport{}
+		transport.Protocol = ProtocolHTTP1
+		client := &http.Client{Transport: transport}
+
+		response, err := client.Get(u)
+		if err != nil {
+			t.Fatalf("%s: %v", u, err)
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := u[len("http://"):]; string(body) != want {
+			t.Errorf("%s: host should be %s and is %s.", u, want, body)
+		}
+	}
+
+	if len(resolved) != 1 || resolved[0] != "resolver.test" {
+		t.Errorf("resolver should be called for resolver.test and is called for %v.", resolved)
+	}
+}
+
+func TestResolveEntry(t *testing.T) {
+	entry := resolveEntry("example.com", "443", []string{"192.0.2.1", "2001:db8::1"})
+	if want := "example.com:443:192.0.2.1,[2001:db8::1]"; entry != want {
+		t.Errorf("entry should be %s and is %s.", want, entry)
+	}
+	if entry = resolveEntry("example.com", "443", nil); entry != "" {
+		t.Errorf("entry without addresses should be empty and is %s.", entry)
+	}
+}
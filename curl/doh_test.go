@@ -0,0 +1,140 @@
+package curl
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// newDoHServer answers the A queries of DNS-over-HTTPS requests with the
+// addresses of hosts, other hosts do not exist.
+func newDoHServer(hosts map[string]net.IP) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, err := ioutil.ReadAll(r.Body)
+		if err != nil || r.Header.Get("Content-Type") != "application/dns-message" || len(query) < 12 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// the question follows the header
+		var labels []string
+		offset := 12
+		for offset < len(query) && query[offset] != 0 {
+			length := int(query[offset])
+			if offset+1+length > len(query) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			labels = append(labels, string(query[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+		offset++
+		if offset+4 > len(query) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		questionType := binary.BigEndian.Uint16(query[offset:])
+		question := query[12 : offset+4]
+
+		ip := hosts[strings.Join(labels, ".")].To4()
+		response := make([]byte, 12, 512)
+		copy(response, query[:2])
+		// a response with recursion, NXDOMAIN for unknown hosts
+		flags := uint16(0x8180)
+		if ip == nil {
+			flags |= 3
+		}
+		binary.BigEndian.PutUint16(response[2:], flags)
+		binary.BigEndian.PutUint16(response[4:], 1)
+		response = append(response, question...)
+		if ip != nil && questionType == 1 {
+			binary.BigEndian.PutUint16(response[6:], 1)
+			// a pointer to the name of the question, type A, class IN, TTL and address
+			response = append(response, 0xc0, 12, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+			response = append(response, ip...)
+		}
+
+		w.Header().Set("Content-Type", "application/dns-message")
+		w.Write(response)
+	}))
+}
+
+func TestTransportDoH(t *testing.T) {
+	doh := newDoHServer(map[string]net.IP{"doh.test": net.IPv4(127, 0, 0, 1)})
+	defer doh.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer ts.Close()
+	serverURL, _ := url.Parse(ts.URL)
+
+	certPEM, _, err := libcurl.CertificatePEM(&tls.Certificate{Certificate: [][]byte{doh.Certificate().Raw}, PrivateKey: doh.TLS.Certificates[0].PrivateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := NewCertPool(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		Protocol:  ProtocolHTTP1,
+		DoHURL:    doh.URL + "/dns-query",
+	}}
+
+	// libcurl only sends DNS-over-HTTPS over TLS
+	if _, err = client.Get(doh.URL); errors.Is(err, libcurl.CurlError(libcurl.E_SSL_CONNECT_ERROR)) {
+		t.Skip("libcurl can not connect to the TLS test server:", err)
+	}
+
+	response, err := client.Get("http://doh.test:" + serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if want := "doh.test:" + serverURL.Port(); string(body) != want {
+		t.Errorf("host should be %s and is %s.", want, body)
+	}
+
+	_, err = client.Get("http://unknown.test:" + serverURL.Port())
+	var dohErr *DoHError
+	if !errors.As(err, &dohErr) {
+		t.Fatalf("an unknown host should fail with a DoHError and is %v.", err)
+	}
+	if dohErr.Host != "unknown.test" || dohErr.Err != libcurl.CurlError(libcurl.E_COULDNT_RESOLVE_HOST) {
+		t.Errorf("error should be for unknown.test and is %v.", dohErr)
+	}
+}
+
+func TestDoHError(t *testing.T) {
+	config := &dnsConfig{DoHURL: "https://dns.example/dns-query"}
+	resolveErr := libcurl.CurlError(libcurl.E_COULDNT_RESOLVE_HOST)
+
+	err := dohError(config, "example.com", resolveErr)
+	if !errors.Is(err, resolveErr) {
+		t.Errorf("error should wrap %v and is %v.", resolveErr, err)
+	}
+	if dohErr, ok := err.(*DoHError); !ok || dohErr.Host != "example.com" || dohErr.URL != config.DoHURL {
+		t.Errorf("error should be a DoHError and is %#v.", err)
+	}
+
+	connectErr := libcurl.CurlError(libcurl.E_COULDNT_CONNECT)
+	if err = dohError(config, "example.com", connectErr); err != connectErr {
+		t.Errorf("other errors should not change and are %v.", err)
+	}
+	if err = dohError(&dnsConfig{}, "example.com", resolveErr); err != resolveErr {
+		t.Errorf("errors without DoH should not change and are %v.", err)
+	}
+}
@@ -0,0 +1,188 @@
+package curl
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// headerParser collects a response header from the lines libcurl passes to
+// OPT_HEADERFUNCTION. libcurl passes the header of every response of a
+// transfer, CONNECT and 1xx responses included, so each status line starts
+// a new header.
+type headerParser struct {
+	Status     string // e.g. "200 OK"
+	StatusCode int
+	Proto      string // e.g. "HTTP/1.1"
+	ProtoMajor int
+	ProtoMinor int
+	Header     http.Header
+
+	lastKey string
+}
+
+func newHeaderParser() *headerParser {
+	return &headerParser{Header: make(http.Header)}
+}
+
+// parseLine parses one header line, it returns true for the blank line
+// which ends the header of a response.
+func (p *headerParser) parseLine(line string) bool {
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return true
+	}
+
+	if strings.HasPrefix(line, "HTTP/") {
+		p.parseStatusLine(line)
+		return false
+	}
+
+	// obsolete line folding continues the previous value
+	if line[0] == ' ' || line[0] == '\t' {
+		if values := p.Header[p.lastKey]; len(values) > 0 {
+			values[len(values)-1] += " " + strings.TrimSpace(line)
+		}
+		return false
+	}
+
+	keyValue := strings.SplitN(line, ":", 2)
+	if len(keyValue) != 2 {
+		return false
+	}
+	key := textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(keyValue[0]))
+	p.Header.Add(key, strings.TrimSpace(keyValue[1]))
+	p.lastKey = key
+	return false
+}
+
+// parseStatusLine resets the parser for the response of line, e.g.
+// "HTTP/1.1 200 OK" or "HTTP/2 200".
+func (p *headerParser) parseStatusLine(line string) {
+	*p = headerParser{Header: make(http.Header)}
+
+	parts := strings.SplitN(line, " ", 3)
+	p.setProto(parts[0])
+
+	if len(parts) > 1 {
+		p.StatusCode, _ = strconv.Atoi(parts[1])
+	}
+
+	reason := ""
+	if len(parts) > 2 {
+		reason = strings.TrimSpace(parts[2])
+	}
+	if reason == "" {
+		reason = http.StatusText(p.StatusCode)
+	}
+	p.Status = strings.TrimSpace(strconv.Itoa(p.StatusCode) + " " + reason)
+}
+
+// setProto parses proto like "HTTP/1.1", "HTTP/2" or "HTTP/3"
+func (p *headerParser) setProto(proto string) {
+	version := strings.SplitN(strings.TrimPrefix(proto, "HTTP/"), ".", 2)
+	major, err := strconv.Atoi(version[0])
+	if err != nil {
+		return
+	}
+
+	minor := 0
+	if len(version) > 1 {
+		if minor, err = strconv.Atoi(version[1]); err != nil {
+			return
+		}
+	}
+
+	p.ProtoMajor = major
+	p.ProtoMinor = minor
+	p.Proto = "HTTP/" + strconv.Itoa(major) + "." + strconv.Itoa(minor)
+}
+
+// protos of the INFO_HTTP_VERSION values
+var httpVersionProtos = map[int]string{
+	libcurl.HTTP_VERSION_1_0: "HTTP/1.0",
+	libcurl.HTTP_VERSION_1_1: "HTTP/1.1",
+	libcurl.HTTP_VERSION_2:   "HTTP/2.0",
+	libcurl.HTTP_VERSION_3:   "HTTP/3.0",
+}
+
+// setHTTPVersion fills the proto from INFO_HTTP_VERSION when the status line had none
+func (p *headerParser) setHTTPVersion(version int) {
+	if p.Proto == "" {
+		p.setProto(httpVersionProtos[version])
+	}
+}
+
+// headers http.Transport writes from the request fields instead of Request.Header
+var requestHeaderExcludes = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+	"Trailer":           true,
+}
+
+// headerLines formats header as "Key: value" lines for OPT_HTTPHEADER and
+// OPT_PROXYHEADER, skipping the keys in excludes. libcurl removes a header
+// given as "Key:", so an empty value is sent as "Key;".
+func headerLines(header http.Header, excludes map[string]bool) []string {
+	lines := make([]string, 0, len(header))
+	for key, values := range header {
+		if excludes[textproto.CanonicalMIMEHeaderKey(key)] {
+			continue
+		}
+		for _, value := range values {
+			if value == "" {
+				lines = append(lines, key+";")
+			} else {
+				lines = append(lines, key+": "+value)
+			}
+		}
+	}
+	return lines
+}
+
+// validateHeader rejects the header names and values http.Transport rejects,
+// a CR or LF in a line for OPT_HTTPHEADER would add a header line.
+func validateHeader(header http.Header) error {
+	for key, values := range header {
+		if !validHeaderFieldName(key) {
+			return fmt.Errorf("curl: invalid header field name %q", key)
+		}
+		for _, value := range values {
+			if !validHeaderFieldValue(value) {
+				return fmt.Errorf("curl: invalid header field value for %q", key)
+			}
+		}
+	}
+	return nil
+}
+
+// validHeaderFieldName reports whether name is a token of RFC 7230
+func validHeaderFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		isToken := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+		if !isToken {
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderFieldValue reports whether value has no control byte but tab
+func validHeaderFieldValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c < ' ' && c != '\t' || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package curl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parseHeaderLines(lines ...string) *headerParser {
+	parser := newHeaderParser()
+	for _, line := range lines {
+		parser.parseLine(line)
+	}
+	return parser
+}
+
+func TestHeaderParser(t *testing.T) {
+	parser := parseHeaderLines(
+		"HTTP/1.1 200 OK\r\n",
+		"Date: Sun, 18 Oct 2026 02:59:00 GMT\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n",
+		"Set-Cookie: a=1\r\n",
+		"set-cookie: b=2\r\n",
+		"X-Folded: first\r\n",
+		"\tsecond\r\n",
+	)
+
+	if parser.Status != "200 OK" || parser.StatusCode != 200 {
+		t.Errorf("status should be %q and is %q, %d.", "200 OK", parser.Status, parser.StatusCode)
+	}
+	if parser.Proto != "HTTP/1.1" || parser.ProtoMajor != 1 || parser.ProtoMinor != 1 {
+		t.Errorf("proto should be HTTP/1.1 and is %q, %d, %d.", parser.Proto, parser.ProtoMajor, parser.ProtoMinor)
+	}
+
+	expected := map[string][]string{
+		"Date":         {"Sun, 18 Oct 2026 02:59:00 GMT"},
+		"Content-Type": {"text/html; charset=utf-8"},
+		"Set-Cookie":   {"a=1", "b=2"},
+		"X-Folded":     {"first second"},
+	}
+	for key, values := range expected {
+		if !reflect.DeepEqual(parser.Header[key], values) {
+			t.Errorf("header %s should be %q and is %q.", key, values, parser.Header[key])
+		}
+	}
+}
+
+func TestHeaderParserResetsOnStatusLine(t *testing.T) {
+	parser := newHeaderParser()
+	for _, line := range []string{"HTTP/1.1 100 Continue\r\n", "X-Interim: 1\r\n"} {
+		parser.parseLine(line)
+	}
+	if !parser.parseLine("\r\n") {
+		t.Error("blank line should end the header.")
+	}
+
+	for _, line := range []string{"HTTP/2 404\r\n", "content-length: 0\r\n"} {
+		parser.parseLine(line)
+	}
+
+	if parser.Status != "404 Not Found" || parser.StatusCode != 404 {
+		t.Errorf("status should be %q and is %q, %d.", "404 Not Found", parser.Status, parser.StatusCode)
+	}
+	if parser.Proto != "HTTP/2.0" || parser.ProtoMajor != 2 || parser.ProtoMinor != 0 {
+		t.Errorf("proto should be HTTP/2.0 and is %q, %d, %d.", parser.Proto, parser.ProtoMajor, parser.ProtoMinor)
+	}
+	if _, ok := parser.Header["X-Interim"]; ok {
+		t.Error("header of the interim response should be dropped.")
+	}
+	if parser.Header.Get("Content-Length") != "0" {
+		t.Errorf("Content-Length should be %q and is %q.", "0", parser.Header.Get("Content-Length"))
+	}
+}
+
+func TestHeaderParserHTTPVersion(t *testing.T) {
+	parser := newHeaderParser()
+	parser.setHTTPVersion(30)
+	if parser.Proto != "HTTP/3.0" || parser.ProtoMajor != 3 {
+		t.Errorf("proto should be HTTP/3.0 and is %q, %d.", parser.Proto, parser.ProtoMajor)
+	}
+}
+
+func TestTransportInvalidHeader(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request with an invalid header should not be sent, %v.", r.Header)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	for _, header := range []http.Header{
+		{"X-Value": {"a\r\nX-Injected: 1"}},
+		{"X-Value": {"a\nb"}},
+		{"X-Value": {"a\x00"}},
+		{"X-Bad Name": {"a"}},
+		{"X-Bad:Name": {"a"}},
+		{"": {"a"}},
+	} {
+		request, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+		request.Header = header
+		if _, err := client.Do(request); err == nil || !strings.Contains(err.Error(), "invalid header field") {
+			t.Errorf("header %q should be rejected and is %v.", header, err)
+		}
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	request.Header.Set("X-Value", "tab\tand spaces ~!")
+	if !validHeaderFieldName("X-Value") || validateHeader(request.Header) != nil {
+		t.Error("valid header should be accepted.")
+	}
+}
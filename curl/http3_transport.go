@@ -1,94 +1,170 @@
 package curl
 
 import (
-	"bytes"
+	"context"
+	"crypto/tls"
 	"errors"
-	"io/ioutil"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/YangSen-qn/go-curl/v2/libcurl"
 )
 
+// like http.Transport, the header of a response is limited to 10MB by default
+const defaultMaxResponseHeaderBytes = 10 << 20
+
+var errResponseHeaderTimeout = errors.New("curl: timeout awaiting response headers")
+
 var (
 	initOnce = sync.Once{}
+	initErr  error
 
 	easyLock sync.Mutex
 )
 
+func globalInit() error {
+	initOnce.Do(func() {
+		initErr = libcurl.GlobalInit(libcurl.GLOBAL_ALL)
+	})
+	return initErr
+}
+
 type http3Transport struct {
-	ResolverList   []string
-	CAPath         string
-	HTTP3LogEnable bool
+	// OPT_RESOLVE and OPT_CONNECT_TO entries
+	ResolverList    []string
+	ConnectToList   []string
+	DNS             dnsConfig
+	CAPath          string
+	TLSClientConfig *tls.Config
+	HTTP3LogEnable  bool
+	// the verbose output of libcurl goes to Logger instead of stderr
+	Logger Logger
+	// OPT_HTTP_VERSION, one of the libcurl.HTTP_VERSION_* constants
+	HTTPVersion int
+	// 单位：ms
 	ConnectTimeout int64
 	Timeout        int64
+	Conn           connConfig
+	// same as the fields of http.Transport
+	ResponseHeaderTimeout  time.Duration
+	ExpectContinueTimeout  time.Duration
+	DisableCompression     bool
+	MaxResponseHeaderBytes int64
+	// port of the Alt-Svc HTTP/3 service, empty to use the port of the URL
+	AltSvcPort string
+	// unix socket the transfer connects to instead of the host of the URL
+	UnixSocket string
+
+	// cookies of Jar are sent and the cookies set by the response are stored in it
+	Jar http.CookieJar
+	// libcurl reads the cookies of CookieFile before and writes them after each transfer
+	CookieFile string
+
+	// same as http.Transport.Proxy and http.Transport.ProxyConnectHeader
+	Proxy              func(*http.Request) (*url.URL, error)
+	ProxyConnectHeader http.Header
+
+	loop *multiLoop
 }
 
 func (t *http3Transport) RoundTrip(request *http.Request) (response *http.Response, err error) {
-	initOnce.Do(func() {
-		err = libcurl.GlobalInit(libcurl.GLOBAL_ALL)
-	})
+	if err = validateHeader(request.Header); err != nil {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+		return
+	}
 
-	easyLock.Lock()
-	easy := libcurl.EasyInit()
-	easyLock.Unlock()
+	if isUpgradeRequest(request) {
+		return t.roundTripUpgrade(request)
+	}
 
+	// once the transfer is started the multi loop cleans up the easy handle
+	// and closes the request body
+	var requestBody *requestBody
+	started := false
 	defer func() {
-		easyLock.Lock()
-		easy.Cleanup()
-		easyLock.Unlock()
+		if started {
+			return
+		}
+		if requestBody != nil {
+			requestBody.close()
+		} else if request.Body != nil {
+			request.Body.Close()
+		}
 	}()
 
-	if easy == nil {
-		err = errors.New("create easy handle error")
+	if err = globalInit(); err != nil {
 		return
 	}
 
-	// request default
-	if t.CAPath != "" {
-		err = easy.Setopt(libcurl.OPT_CAPATH, t.CAPath)
-		if err != nil {
-			return
-		}
-	}
+	easyLock.Lock()
+	easy := libcurl.EasyInit()
+	easyLock.Unlock()
 
-	if t.HTTP3LogEnable {
-		err = easy.Setopt(libcurl.OPT_VERBOSE, 1)
-		if err != nil {
-			return
-		}
+	if easy == nil {
+		err = errors.New("create easy handle error")
+		return
 	}
 
-	err = easy.Setopt(libcurl.OPT_SSL_VERIFYHOST, 0) // 0 is ok
-	if err != nil {
+	ctx := request.Context()
+	if err = ctx.Err(); err != nil {
+		cleanupEasy(easy)
 		return
 	}
 
-	err = easy.Setopt(libcurl.OPT_SSL_VERIFYPEER, 0) // 0 is ok
+	defer func() {
+		if !started {
+			cleanupEasy(easy)
+		}
+	}()
+
+	// like http.Transport, only https requests are tunneled
+	requestURL, proxyURL, resolveHost, err := t.setupEasy(easy, request, t.HTTPVersion, request.URL.Scheme == "https")
 	if err != nil {
 		return
 	}
 
-	err = easy.Setopt(libcurl.OPT_HTTP_VERSION, libcurl.HTTP_VERSION_3)
+	// wait for a connection which can be multiplexed instead of opening a new one
+	err = easy.Setopt(libcurl.OPT_PIPEWAIT, 1)
 	if err != nil {
 		return
 	}
 
-	// request url
-	err = easy.Setopt(libcurl.OPT_URL, request.URL.String())
+	// cookies
+	cookies, err := setupCookies(easy, request.URL, requestURL, t.Jar, t.CookieFile)
 	if err != nil {
 		return
 	}
 
-	// method
-	switch request.Method {
-	case http.MethodGet:
-		err = easy.Setopt(libcurl.OPT_HTTPGET, 1)
-	case http.MethodPost:
+	// method and body
+	bodyLength := outgoingLength(request)
+	switch {
+	case request.Method == http.MethodHead:
+		err = easy.Setopt(libcurl.OPT_NOBODY, 1)
+	case request.Method == http.MethodPost:
 		err = easy.Setopt(libcurl.OPT_POST, 1)
-	case http.MethodPut:
+		if err != nil {
+			return
+		}
+		// -1 is sent chunked over HTTP/1.1
+		err = easy.Setopt(libcurl.OPT_POSTFIELDSIZE_LARGE, bodyLength)
+	case request.Method == http.MethodPut || bodyLength != 0:
 		err = easy.Setopt(libcurl.OPT_UPLOAD, 1)
+		if err != nil {
+			return
+		}
+		err = easy.Setopt(libcurl.OPT_INFILESIZE_LARGE, bodyLength)
+		if err == nil && request.Method != http.MethodPut {
+			err = easy.Setopt(libcurl.OPT_CUSTOMREQUEST, request.Method)
+		}
+	case request.Method == http.MethodGet || request.Method == "":
+		err = easy.Setopt(libcurl.OPT_HTTPGET, 1)
 	default:
 		err = easy.Setopt(libcurl.OPT_CUSTOMREQUEST, request.Method)
 	}
@@ -97,70 +173,168 @@ func (t *http3Transport) RoundTrip(request *http.Request) (response *http.Respon
 	}
 
 	// request header
-	requestHeader := make([]string, len(request.Header))
-	for key, _ := range request.Header {
-		requestHeader = append(requestHeader, key+":"+request.Header.Get(key))
+	sendHeader := request.Header
+	if cookies != nil {
+		sendHeader = cookies.requestHeader(sendHeader)
+	}
+	requestHeader := headerLines(sendHeader, requestHeaderExcludes)
+	if host := request.Host; host != "" && host != requestURL.Host {
+		requestHeader = append(requestHeader, "Host: "+host)
+	} else if requestURL != request.URL {
+		requestHeader = append(requestHeader, "Host: "+request.URL.Host)
+	}
+	// like http.Transport, only wait for 100-continue when asked to
+	if _, ok := sendHeader["Expect"]; !ok {
+		requestHeader = append(requestHeader, "Expect:")
+	}
+	// OPT_POST adds a form Content-Type, http.Transport sends none
+	if _, ok := sendHeader["Content-Type"]; !ok && request.Method == http.MethodPost {
+		requestHeader = append(requestHeader, "Content-Type:")
 	}
 	err = easy.Setopt(libcurl.OPT_HTTPHEADER, requestHeader)
 	if err != nil {
 		return
 	}
 
-	if body := request.Body; body != nil {
-		switch request.Method {
-		case http.MethodPost:
-			err = easy.Setopt(libcurl.OPT_POSTFIELDSIZE, request.ContentLength)
-		case http.MethodPut:
-			err = easy.Setopt(libcurl.OPT_INFILESIZE, request.ContentLength)
-		default:
+	// like http.Transport, ask for a compressed response and let libcurl decode
+	// it, unless the caller handles the encoding itself
+	decompress := !t.DisableCompression && request.Method != http.MethodHead &&
+		sendHeader.Get("Accept-Encoding") == "" && sendHeader.Get("Range") == ""
+	if decompress {
+		err = easy.Setopt(libcurl.OPT_ACCEPT_ENCODING, "gzip, deflate")
+		if err != nil {
+			return
 		}
 	}
 
-	// request resolver
-	if len(t.ResolverList) > 0 {
-		resolverList := make([]string, 10)
-
-		for _, resolver := range t.ResolverList {
-			resolverList = append(resolverList, resolver)
+	// a context deadline earlier than Timeout becomes the transfer timeout
+	timeout := t.Timeout
+	timeoutFromDeadline := false
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := int64(time.Until(deadline) / time.Millisecond)
+		if remaining < 1 {
+			remaining = 1
 		}
-
-		err = easy.Setopt(libcurl.OPT_RESOLVE, resolverList)
-		if err != nil {
-			return
+		if timeout <= 0 || remaining < timeout {
+			timeout = remaining
+			timeoutFromDeadline = true
 		}
 	}
 
-	if t.Timeout > 0 {
-		err = easy.Setopt(libcurl.OPT_TIMEOUT_MS, t.Timeout)
+	if timeout > 0 {
+		err = easy.Setopt(libcurl.OPT_TIMEOUT_MS, timeout)
 	}
 
 	if err != nil {
 		return
 	}
 
-	if t.ConnectTimeout > 0 {
-		err = easy.Setopt(libcurl.OPT_CONNECTTIMEOUT_MS, t.ConnectTimeout)
+	// like http.Transport the body is sent right away without ExpectContinueTimeout
+	if _, ok := sendHeader["Expect"]; ok {
+		err = easy.Setopt(libcurl.OPT_EXPECT_100_TIMEOUT_MS, int64(t.ExpectContinueTimeout/time.Millisecond))
+		if err != nil {
+			return
+		}
 	}
 
-	if err != nil {
-		return
+	maxHeaderBytes := t.maxResponseHeaderBytes()
+
+	var tlsState *tls.ConnectionState
+	header := newHeaderParser()
+	headerDone := make(chan struct{})
+	performDone := make(chan struct{})
+	xfer := &transfer{easy: easy}
+	headerBytes := int64(0)
+	// started once the request is written, only accessed on the loop goroutine
+	var headerTimer *time.Timer
+	quic := t.HTTPVersion == libcurl.HTTP_VERSION_3 && requestURL.Scheme == "https" && proxyURL == nil && t.UnixSocket == ""
+	trace := newClientTrace(ctx, easy, requestURL, quic)
+	responseBody := newResponseBody(func() {
+		t.loop.resume(xfer)
+	}, func(err error) {
+		t.loop.abort(xfer, err)
+	})
+	xfer.done = func(performErr error) {
+		if timeoutFromDeadline && performErr == libcurl.CurlError(libcurl.E_OPERATION_TIMEDOUT) {
+			performErr = context.DeadlineExceeded
+		}
+		if resolveHost != "" {
+			performErr = dohError(&t.DNS, resolveHost, performErr)
+		}
+		if headerBytes > maxHeaderBytes {
+			performErr = responseHeaderLimitError(maxHeaderBytes)
+		}
+		if headerTimer != nil {
+			headerTimer.Stop()
+		}
+		if trace != nil {
+			trace.finish(performErr)
+		}
+		if sink := transferInfoSink(ctx); sink != nil {
+			if info, infoErr := easy.TransferInfo(); infoErr == nil {
+				sink(info)
+			}
+		}
+		select {
+		case <-headerDone:
+		default:
+			finishHeader(easy, header)
+		}
+		if requestBody != nil {
+			requestBody.close()
+		} else if request.Body != nil {
+			request.Body.Close()
+		}
+		// the origin of an Alt-Svc attempt is only marked broken for these
+		if performErr != nil && t.AltSvcPort != "" && !requestStarted(easy) {
+			performErr = &altSvcConnectError{err: performErr}
+		}
+		responseBody.finish(performErr)
+		close(performDone)
+		cleanupEasy(easy)
 	}
-
-	responseHeader := make(http.Header)
-	responseBody := new(bytes.Buffer)
 	err = easy.Setopt(libcurl.OPT_HEADERFUNCTION, func(headField []byte, userData interface{}) bool {
-		keyValue := string(headField)
-		keyValueList := strings.SplitN(keyValue, ":", 2)
-		if len(keyValueList) != 2 {
+		select {
+		case <-headerDone:
+			return true
+		default:
+		}
+
+		if trace != nil {
+			trace.firstResponseByte()
+		}
+
+		// returning false would only pause the transfer, it is aborted once
+		headerBytes += int64(len(headField))
+		if headerBytes > maxHeaderBytes {
+			if headerBytes-int64(len(headField)) <= maxHeaderBytes {
+				t.loop.abort(xfer, responseHeaderLimitError(maxHeaderBytes))
+			}
+			return true
+		}
+
+		if !header.parseLine(string(headField)) {
 			return true
 		}
-		key := keyValueList[0]
-		value := keyValueList[1]
-		value = strings.ReplaceAll(value, " ", "")
-		value = strings.ReplaceAll(value, "\r", "")
-		value = strings.ReplaceAll(value, "\n", "")
-		responseHeader.Set(key, value)
 
+		// the blank line ends the header of a response, wait for the final one.
+		// the response code is not set by the header of a CONNECT response.
+		statusCodeI, _ := easy.Getinfo(libcurl.INFO_RESPONSE_CODE)
+		statusCode, _ := statusCodeI.(int)
+		if statusCode < http.StatusOK {
+			if trace != nil && header.StatusCode >= 100 && header.StatusCode < http.StatusOK {
+				trace.informational(header.StatusCode, header.Header)
+			}
+		} else {
+			finishHeader(easy, header)
+			if requestURL.Scheme == "https" {
+				tlsState = connectionState(easy, requestURL.Hostname())
+			}
+			if cookies != nil {
+				cookies.collect(easy)
+			}
+			close(headerDone)
+		}
 		return true
 	})
 	if err != nil {
@@ -168,56 +342,274 @@ func (t *http3Transport) RoundTrip(request *http.Request) (response *http.Respon
 	}
 
 	err = easy.Setopt(libcurl.OPT_WRITEFUNCTION, func(buff []byte, userData interface{}) bool {
-		_, err := responseBody.Write(buff)
-		if err != nil {
-			return false
-		} else {
-			return true
-		}
+		return responseBody.write(buff)
 	})
 	if err != nil {
 		return
 	}
 
-	err = easy.Setopt(libcurl.OPT_READFUNCTION, func(buff []byte, userData interface{}) int {
-		if request.Body == nil {
-			return 0
+	if bodyLength != 0 && request.Method != http.MethodHead {
+		requestBody = newRequestBody(request.Body, bodyLength, request.GetBody, func() {
+			t.loop.resume(xfer)
+		})
+
+		err = easy.Setopt(libcurl.OPT_READFUNCTION, func(buff []byte, userData interface{}) int {
+			n := requestBody.read(buff)
+			// trace WroteRequest without waiting for the next loop iteration
+			if trace != nil && requestBody.sentAll() {
+				trace.poll()
+			}
+			return n
+		})
+		if err != nil {
+			return
 		}
 
-		len, err := request.Body.Read(buff)
-		if err == nil {
-			return len
-		} else {
-			return 0
+		err = easy.Setopt(libcurl.OPT_SEEKFUNCTION, func(offset int64, origin int, userData interface{}) int {
+			return requestBody.seek(offset, origin)
+		})
+		if err != nil {
+			return
 		}
-	})
+	}
+
+	// the hooks of trace are run here while waiting for the header
+	var traceHooks <-chan struct{}
+	if trace != nil {
+		trace.body = requestBody
+		xfer.progress = trace.poll
+		trace.getConn()
+		traceHooks = trace.queued
+		defer trace.close()
+	}
+
+	if t.ResponseHeaderTimeout > 0 {
+		progress := xfer.progress
+		xfer.progress = func() {
+			if progress != nil {
+				progress()
+			}
+			if headerTimer == nil && requestWritten(easy, requestBody) {
+				headerTimer = time.AfterFunc(t.ResponseHeaderTimeout, func() {
+					t.loop.abortUnless(xfer, headerDone, errResponseHeaderTimeout)
+				})
+			}
+		}
+	}
+
+	started = true
+	t.loop.start(xfer)
+
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				responseBody.abort(ctx.Err())
+			case <-performDone:
+			}
+		}()
+	}
+
+	for waiting := true; waiting; {
+		select {
+		case <-traceHooks:
+			trace.run()
+		case <-headerDone:
+			if cookies != nil {
+				cookies.sync(header.Header)
+			}
+			waiting = false
+		case <-performDone:
+			if err = responseBody.failure(); err != nil {
+				return
+			}
+			waiting = false
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+
+	// libcurl fails the transfer on an encoding it can not decode, so the
+	// body is decoded and the length of the encoded body does not apply
+	uncompressed := false
+	if decompress {
+		if encoding := header.Header.Get("Content-Encoding"); encoding != "" && !strings.EqualFold(encoding, "identity") {
+			header.Header.Del("Content-Encoding")
+			header.Header.Del("Content-Length")
+			uncompressed = true
+		}
+	}
+
+	contentLength := int64(-1)
+	if value := header.Header.Get("Content-Length"); value != "" {
+		if length, pErr := strconv.ParseInt(value, 10, 64); pErr == nil {
+			contentLength = length
+		}
+	}
+
+	response = &http.Response{
+		Status:           header.Status,
+		StatusCode:       header.StatusCode,
+		Proto:            header.Proto,
+		ProtoMajor:       header.ProtoMajor,
+		ProtoMinor:       header.ProtoMinor,
+		Header:           header.Header,
+		Body:             responseBody,
+		ContentLength:    contentLength,
+		TransferEncoding: nil,
+		Close:            false,
+		Uncompressed:     uncompressed,
+		Trailer:          nil,
+		Request:          request,
+		TLS:              tlsState,
+	}
+
+	return
+}
+
+// setupEasy sets the options RoundTrip and roundTripUpgrade share on easy,
+// from TLS and the proxy to the URL, DNS and the connection settings.
+// tunnel makes a HTTP proxy tunnel the transfer. resolveHost is the host
+// pre-resolved for the transfer, empty when libcurl does not resolve it.
+func (t *http3Transport) setupEasy(easy *libcurl.CURL, request *http.Request, httpVersion int, tunnel bool) (requestURL, proxyURL *url.URL, resolveHost string, err error) {
+	if t.CAPath != "" {
+		err = easy.Setopt(libcurl.OPT_CAPATH, t.CAPath)
+		if err != nil {
+			return
+		}
+	}
+
+	err = setupVerbose(easy, t.HTTP3LogEnable, t.Logger)
 	if err != nil {
 		return
 	}
 
-	err = easy.Perform()
+	err = setupTLS(easy, t.TLSClientConfig)
+	if err != nil {
+		return
+	}
 
-	if err == nil {
-		statusCodeI, _ := easy.Getinfo(libcurl.INFO_HTTP_CODE)
-		statusCode, _ := statusCodeI.(int)
+	err = setupUnixSocket(easy, t.UnixSocket)
+	if err != nil {
+		return
+	}
+
+	// a unix socket is local, it is never proxied
+	if t.Proxy != nil && t.UnixSocket == "" {
+		proxyURL, err = t.Proxy(request)
+		if err != nil {
+			return
+		}
+	}
+
+	err = setupProxy(easy, proxyURL, tunnel, t.ProxyConnectHeader, t.TLSClientConfig)
+	if err != nil {
+		return
+	}
 
-		response = &http.Response{
-			Status:           "",
-			StatusCode:       statusCode,
-			Proto:            "HTTP/3",
-			ProtoMajor:       0,
-			ProtoMinor:       0,
-			Header:           responseHeader,
-			Body:             ioutil.NopCloser(responseBody),
-			ContentLength:    int64(responseBody.Len()),
-			TransferEncoding: nil,
-			Close:            false,
-			Uncompressed:     false,
-			Trailer:          nil,
-			Request:          request,
-			TLS:              nil,
+	err = easy.Setopt(libcurl.OPT_HTTP_VERSION, httpVersion)
+	// libcurl built without HTTP/2 rejects it, these versions allow HTTP/1.1 anyway
+	if err == libcurl.CurlError(libcurl.E_UNSUPPORTED_PROTOCOL) &&
+		(httpVersion == libcurl.HTTP_VERSION_2 || httpVersion == libcurl.HTTP_VERSION_2TLS) {
+		err = easy.Setopt(libcurl.OPT_HTTP_VERSION, libcurl.HTTP_VERSION_1_1)
+	}
+	if err != nil {
+		return
+	}
+
+	// request url
+	requestURL, connectTo := serverNameURL(request.URL, t.TLSClientConfig)
+	if t.AltSvcPort != "" && t.AltSvcPort != originPort(request.URL) {
+		connectTo = altSvcConnectTo(requestURL, request.URL, t.AltSvcPort)
+	}
+	err = easy.Setopt(libcurl.OPT_URL, requestURL.String())
+	if err != nil {
+		return
+	}
+
+	// the rewrite of the URL comes first, libcurl uses the first matching rule
+	connectToList := t.ConnectToList
+	if connectTo != "" {
+		connectToList = append([]string{connectTo}, t.ConnectToList...)
+	}
+	if len(connectToList) > 0 {
+		err = easy.Setopt(libcurl.OPT_CONNECT_TO, connectToList)
+		if err != nil {
+			return
+		}
+	}
+
+	// collect the peer certificates for response.TLS
+	if requestURL.Scheme == "https" {
+		err = easy.Setopt(libcurl.OPT_CERTINFO, 1)
+		if err != nil {
+			return
 		}
 	}
 
+	// request resolver, the host is pre-resolved when libcurl connects to it
+	resolveHost, resolvePort := request.URL.Hostname(), originPort(request.URL)
+	if t.AltSvcPort != "" {
+		resolvePort = t.AltSvcPort
+	}
+	if proxyURL != nil || t.UnixSocket != "" {
+		resolveHost = ""
+	}
+	err = setupDNS(request.Context(), easy, &t.DNS, t.ResolverList, resolveHost, resolvePort)
+	if err != nil {
+		return
+	}
+
+	if t.ConnectTimeout > 0 {
+		err = easy.Setopt(libcurl.OPT_CONNECTTIMEOUT_MS, t.ConnectTimeout)
+		if err != nil {
+			return
+		}
+	}
+
+	err = setupConnection(easy, &t.Conn)
 	return
 }
+
+func (t *http3Transport) maxResponseHeaderBytes() int64 {
+	if t.MaxResponseHeaderBytes > 0 {
+		return t.MaxResponseHeaderBytes
+	}
+	return defaultMaxResponseHeaderBytes
+}
+
+func responseHeaderLimitError(maxHeaderBytes int64) error {
+	return fmt.Errorf("curl: server response headers exceeded %d bytes; aborted", maxHeaderBytes)
+}
+
+// requestWritten reports whether libcurl sent the whole request
+func requestWritten(easy *libcurl.CURL, body *requestBody) bool {
+	return requestStarted(easy) && (body == nil || body.sentAll())
+}
+
+// requestStarted reports whether libcurl connected and started to send the request
+func requestStarted(easy *libcurl.CURL) bool {
+	preTransferTimeI, _ := easy.Getinfo(libcurl.INFO_PRETRANSFER_TIME)
+	preTransferTime, _ := preTransferTimeI.(float64)
+	return preTransferTime > 0
+}
+
+// finishHeader completes the parsed header of the final response from libcurl
+func finishHeader(easy *libcurl.CURL, header *headerParser) {
+	if header.StatusCode == 0 {
+		statusCodeI, _ := easy.Getinfo(libcurl.INFO_RESPONSE_CODE)
+		header.StatusCode, _ = statusCodeI.(int)
+		header.Status = strings.TrimSpace(strconv.Itoa(header.StatusCode) + " " + http.StatusText(header.StatusCode))
+	}
+
+	versionI, _ := easy.Getinfo(libcurl.INFO_HTTP_VERSION)
+	version, _ := versionI.(int)
+	header.setHTTPVersion(version)
+}
+
+func cleanupEasy(easy *libcurl.CURL) {
+	easyLock.Lock()
+	easy.Cleanup()
+	easyLock.Unlock()
+}
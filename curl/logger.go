@@ -0,0 +1,52 @@
+package curl
+
+import (
+	"strings"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// Logger receives the verbose output of libcurl, a *log.Logger is one.
+// It is called by the transfers of all requests, concurrently.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// like curl -v, the data of the transfer is not logged
+var debugPrefixes = map[libcurl.DebugType]string{
+	libcurl.DEBUG_TEXT:       "* ",
+	libcurl.DEBUG_HEADER_OUT: "> ",
+	libcurl.DEBUG_HEADER_IN:  "< ",
+}
+
+// setupVerbose turns on the verbose output of libcurl, which goes to logger
+// or to stderr without one
+func setupVerbose(easy *libcurl.CURL, verbose bool, logger Logger) (err error) {
+	if !verbose && logger == nil {
+		return
+	}
+
+	err = easy.Setopt(libcurl.OPT_VERBOSE, 1)
+	if err != nil || logger == nil {
+		return
+	}
+
+	return easy.Setopt(libcurl.OPT_DEBUGFUNCTION, func(debugType libcurl.DebugType, data []byte, userData interface{}) {
+		logDebug(logger, debugType, data)
+	})
+}
+
+// logDebug logs each line of data with the prefix of its type
+func logDebug(logger Logger, debugType libcurl.DebugType, data []byte) {
+	prefix, ok := debugPrefixes[debugType]
+	if !ok {
+		return
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			logger.Printf("%s%s", prefix, line)
+		}
+	}
+}
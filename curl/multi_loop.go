@@ -0,0 +1,248 @@
+package curl
+
+import (
+	"sync"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// how long the loop sleeps in curl_multi_poll when nothing happens
+const multiPollTimeout = 1000 // 单位：ms
+
+// transfer is an easy handle driven by a multiLoop.
+type transfer struct {
+	easy *libcurl.CURL
+	// called on the loop goroutine once the handle is removed from the multi handle
+	done func(error)
+	// called on the loop goroutine after each perform while the transfer runs, may be nil
+	progress func()
+
+	// only accessed on the loop goroutine
+	active bool
+}
+
+// multiLoop drives the transfers of a Transport on one long-lived multi
+// handle, so connections are reused and multiplexed across requests.
+// libcurl is only called from the loop goroutine, other goroutines queue
+// commands and wake it up. The goroutine exits when it has nothing to do
+// and is started again by the next command.
+type multiLoop struct {
+	maxConnsPerHost int
+	maxConns        int
+
+	mu       sync.Mutex
+	running  bool
+	closing  bool
+	commands []func()
+	// nil once closeIdle cleaned it up, the next transfer creates a new one.
+	// written under mu, read on the loop goroutine or under mu
+	multi *libcurl.CURLM
+
+	// keyed by easy handle, only accessed on the loop goroutine
+	transfers map[uintptr]*transfer
+}
+
+// newMultiLoop limits the connections to a host to maxConnsPerHost and the
+// connections libcurl keeps open to maxConns, zero means no limit.
+func newMultiLoop(maxConnsPerHost, maxConns int) (*multiLoop, error) {
+	multi, err := newMulti(maxConnsPerHost, maxConns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &multiLoop{
+		maxConnsPerHost: maxConnsPerHost,
+		maxConns:        maxConns,
+		multi:           multi,
+		transfers:       make(map[uintptr]*transfer),
+	}, nil
+}
+
+func newMulti(maxConnsPerHost, maxConns int) (*libcurl.CURLM, error) {
+	if err := globalInit(); err != nil {
+		return nil, err
+	}
+
+	multi := libcurl.MultiInit()
+	if err := multi.Setopt(libcurl.MOPT_PIPELINING, libcurl.PIPE_MULTIPLEX); err != nil {
+		multi.Cleanup()
+		return nil, err
+	}
+
+	if maxConnsPerHost > 0 {
+		if err := multi.Setopt(libcurl.MOPT_MAX_HOST_CONNECTIONS, maxConnsPerHost); err != nil {
+			multi.Cleanup()
+			return nil, err
+		}
+	}
+
+	if maxConns > 0 {
+		if err := multi.Setopt(libcurl.MOPT_MAXCONNECTS, maxConns); err != nil {
+			multi.Cleanup()
+			return nil, err
+		}
+	}
+	return multi, nil
+}
+
+// start adds the transfer to the multi handle, t.done is called when it is over.
+func (l *multiLoop) start(t *transfer) {
+	l.do(func() {
+		if l.multi == nil {
+			multi, err := newMulti(l.maxConnsPerHost, l.maxConns)
+			if err != nil {
+				t.done(err)
+				return
+			}
+			l.mu.Lock()
+			l.multi = multi
+			l.mu.Unlock()
+		}
+
+		if err := l.multi.AddHandle(t.easy); err != nil {
+			t.done(err)
+			return
+		}
+
+		t.active = true
+		l.transfers[uintptr(t.easy.GetHandle())] = t
+	})
+}
+
+// resume continues a transfer paused by its write callback.
+func (l *multiLoop) resume(t *transfer) {
+	l.do(func() {
+		if !t.active {
+			return
+		}
+
+		if err := t.easy.Pause(libcurl.PAUSE_CONT); err != nil {
+			l.finish(t, err)
+		}
+	})
+}
+
+// abort stops a transfer which is still running, t.done gets err.
+func (l *multiLoop) abort(t *transfer, err error) {
+	l.do(func() {
+		if t.active {
+			l.finish(t, err)
+		}
+	})
+}
+
+// abortUnless stops a transfer which is still running unless done is closed,
+// done has to be closed on the loop goroutine.
+func (l *multiLoop) abortUnless(t *transfer, done <-chan struct{}, err error) {
+	l.do(func() {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if t.active {
+			l.finish(t, err)
+		}
+	})
+}
+
+// closeIdle cleans up the multi handle, which closes its idle connections.
+// With running transfers it happens once they are all over, the next
+// transfer creates a new multi handle.
+func (l *multiLoop) closeIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closing = true
+	if !l.running {
+		l.cleanup()
+	} else if l.multi != nil {
+		l.multi.Wakeup()
+	}
+}
+
+// cleanup is called with mu held, on the loop goroutine or while it is not running
+func (l *multiLoop) cleanup() {
+	l.closing = false
+	if l.multi != nil {
+		l.multi.Cleanup()
+		l.multi = nil
+	}
+}
+
+func (l *multiLoop) do(command func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commands = append(l.commands, command)
+	if !l.running {
+		l.running = true
+		go l.run()
+		return
+	}
+
+	// under mu, so the loop can not clean up the multi handle meanwhile
+	if l.multi != nil {
+		l.multi.Wakeup()
+	}
+}
+
+func (l *multiLoop) finish(t *transfer, err error) {
+	l.multi.RemoveHandle(t.easy)
+	t.active = false
+	delete(l.transfers, uintptr(t.easy.GetHandle()))
+	t.done(err)
+}
+
+func (l *multiLoop) run() {
+	for {
+		l.mu.Lock()
+		commands := l.commands
+		l.commands = nil
+		if l.closing && len(l.transfers) == 0 {
+			l.cleanup()
+		}
+		if len(commands) == 0 && len(l.transfers) == 0 {
+			l.running = false
+			l.mu.Unlock()
+			return
+		}
+		l.mu.Unlock()
+
+		for _, command := range commands {
+			command()
+		}
+
+		if len(l.transfers) == 0 {
+			continue
+		}
+
+		if _, err := l.multi.Perform(); err != nil {
+			for _, t := range l.transfers {
+				l.finish(t, err)
+			}
+			continue
+		}
+
+		for {
+			msg, _ := l.multi.Info_read()
+			if msg == nil {
+				break
+			}
+			if msg.Msg != libcurl.CURLMSG_DONE {
+				continue
+			}
+			if t := l.transfers[uintptr(msg.Easy_handle.GetHandle())]; t != nil {
+				l.finish(t, msg.Result())
+			}
+		}
+
+		for _, t := range l.transfers {
+			if t.progress != nil {
+				t.progress()
+			}
+		}
+
+		l.multi.Poll(multiPollTimeout)
+	}
+}
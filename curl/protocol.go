@@ -0,0 +1,38 @@
+package curl
+
+import "github.com/YangSen-qn/go-curl/v2/libcurl"
+
+// Protocol selects how Transport sends requests
+type Protocol int
+
+const (
+	// ProtocolTransport sends every request with http.Transport
+	ProtocolTransport Protocol = iota
+	// ProtocolHTTP1 uses libcurl with HTTP/1.1
+	ProtocolHTTP1
+	// ProtocolHTTP2 uses libcurl with HTTP/2, over TLS when the server
+	// negotiates it and by upgrade for http URLs, HTTP/1.1 otherwise
+	ProtocolHTTP2
+	// ProtocolHTTP2TLS uses libcurl with HTTP/2 over TLS and HTTP/1.1 for http URLs
+	ProtocolHTTP2TLS
+	// ProtocolH2C uses libcurl with HTTP/2 prior knowledge, for http URLs
+	// it speaks HTTP/2 without the upgrade
+	ProtocolH2C
+	// ProtocolHTTP3 uses libcurl with HTTP/3 only, there is no fallback
+	ProtocolHTTP3
+	// ProtocolHTTP3Upgrade sends requests with http.Transport until an https
+	// origin advertises h3 in Alt-Svc, later requests to the origin use
+	// HTTP/3 with libcurl. A request whose HTTP/3 connection fails is sent
+	// again with http.Transport and the origin is not upgraded for
+	// Transport.HTTP3BrokenTimeout.
+	ProtocolHTTP3Upgrade
+)
+
+// OPT_HTTP_VERSION of the protocols sent with libcurl
+var protocolHTTPVersions = map[Protocol]int{
+	ProtocolHTTP1:    libcurl.HTTP_VERSION_1_1,
+	ProtocolHTTP2:    libcurl.HTTP_VERSION_2,
+	ProtocolHTTP2TLS: libcurl.HTTP_VERSION_2TLS,
+	ProtocolH2C:      libcurl.HTTP_VERSION_2_PRIOR_KNOWLEDGE,
+	ProtocolHTTP3:    libcurl.HTTP_VERSION_3,
+}
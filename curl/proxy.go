@@ -0,0 +1,146 @@
+package curl
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// OPT_PROXYTYPE of the proxy URL schemes. Like http.Transport, a socks5
+// proxy resolves the host names, it is socks5h for libcurl.
+var proxyTypes = map[string]int{
+	"http":    libcurl.PROXY_HTTP,
+	"https":   libcurl.PROXY_HTTPS,
+	"socks4":  libcurl.PROXY_SOCKS4,
+	"socks4a": libcurl.PROXY_SOCKS4A,
+	"socks5":  libcurl.PROXY_SOCKS5_HOSTNAME,
+	"socks5h": libcurl.PROXY_SOCKS5_HOSTNAME,
+}
+
+// like canonicalAddr of net/http, a proxy URL without port uses the
+// default port of its scheme instead of the 1080 of libcurl
+var proxyPorts = map[string]string{
+	"http":   "80",
+	"https":  "443",
+	"socks5": "1080",
+}
+
+// proxyAddr is the host and port of proxyURL for OPT_PROXY
+func proxyAddr(proxyURL *url.URL) string {
+	port := proxyURL.Port()
+	if port == "" {
+		port = proxyPorts[proxyURL.Scheme]
+	}
+	if port == "" {
+		return proxyURL.Host
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+// setupProxy sends the transfer through proxyURL, nil for a direct
+// connection. libcurl would read the proxy environment variables itself
+// when the options are unset, so they are always set and the Proxy func of
+// the Transport stays the only one to decide. tunnel makes a HTTP proxy
+// tunnel the transfer with CONNECT, which is the only request
+// connectHeader is sent with.
+func setupProxy(easy *libcurl.CURL, proxyURL *url.URL, tunnel bool, connectHeader http.Header, config *tls.Config) (err error) {
+	if proxyURL == nil {
+		err = easy.Setopt(libcurl.OPT_PROXY, "")
+		return
+	}
+
+	proxyType, ok := proxyTypes[proxyURL.Scheme]
+	if !ok {
+		return fmt.Errorf("curl: unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	// the credentials go to their own options and the type to OPT_PROXYTYPE,
+	// a scheme in OPT_PROXY would override it
+	err = easy.Setopt(libcurl.OPT_PROXY, proxyAddr(proxyURL))
+	if err != nil {
+		return
+	}
+
+	err = easy.Setopt(libcurl.OPT_PROXYTYPE, proxyType)
+	if err != nil {
+		return
+	}
+
+	err = easy.Setopt(libcurl.OPT_NOPROXY, "")
+	if err != nil {
+		return
+	}
+
+	if user := proxyURL.User; user != nil {
+		err = easy.Setopt(libcurl.OPT_PROXYUSERNAME, user.Username())
+		if err != nil {
+			return
+		}
+
+		password, _ := user.Password()
+		err = easy.Setopt(libcurl.OPT_PROXYPASSWORD, password)
+		if err != nil {
+			return
+		}
+	}
+
+	if tunnel && (proxyType == libcurl.PROXY_HTTP || proxyType == libcurl.PROXY_HTTPS) {
+		err = easy.Setopt(libcurl.OPT_HTTPPROXYTUNNEL, 1)
+		if err != nil {
+			return
+		}
+
+		if len(connectHeader) > 0 {
+			err = easy.Setopt(libcurl.OPT_HEADEROPT, libcurl.HEADER_SEPARATE)
+			if err != nil {
+				return
+			}
+
+			err = easy.Setopt(libcurl.OPT_PROXYHEADER, headerLines(connectHeader, nil))
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// like http.Transport, a https proxy is verified with the TLS config of the requests
+	if proxyType == libcurl.PROXY_HTTPS && config != nil {
+		if config.InsecureSkipVerify {
+			err = easy.Setopt(libcurl.OPT_PROXY_SSL_VERIFYPEER, 0)
+			if err != nil {
+				return
+			}
+
+			err = easy.Setopt(libcurl.OPT_PROXY_SSL_VERIFYHOST, 0)
+			if err != nil {
+				return
+			}
+		}
+
+		if config.RootCAs != nil {
+			var caPath string
+			caPath, err = caBundlePath(config.RootCAs)
+			if err != nil {
+				return
+			}
+
+			err = easy.Setopt(libcurl.OPT_PROXY_CAINFO, caPath)
+			if err != nil {
+				return
+			}
+		}
+
+		if len(config.Certificates) > 0 {
+			err = easy.SetProxyClientCertificate(&config.Certificates[0])
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,192 @@
+package curl
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestProxyConnectHeader(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Method+" "+r.Host] = r.Header.Get("X-Connect")
+		mu.Unlock()
+		if r.Method == http.MethodConnect {
+			// the tunnel is closed right away, only its request is checked
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{
+			Proxy:              http.ProxyURL(proxyURL),
+			ProxyConnectHeader: http.Header{"X-Connect": {"1"}},
+		},
+		Protocol: ProtocolHTTP1,
+	}}
+
+	response, err := client.Get("http://proxied.test/path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if string(body) != "proxied http://proxied.test/path" {
+		t.Errorf("http request should be sent to the proxy and is %q.", body)
+	}
+
+	if _, err = client.Get("https://proxied.test/path"); err == nil {
+		t.Error("https request should fail with the refused tunnel.")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if header, ok := seen["GET proxied.test"]; !ok || header != "" {
+		t.Errorf("http request should be proxied without the CONNECT header, %v.", seen)
+	}
+	if header := seen["CONNECT proxied.test:443"]; header != "1" {
+		t.Errorf("CONNECT should have the CONNECT header, %v.", seen)
+	}
+}
+
+// serveSOCKS5 accepts one connection of a socks5 proxy without
+// authentication, it returns the requested host and connects to target
+func serveSOCKS5(t *testing.T, listener net.Listener, target string, host chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// greeting: version, the methods, no authentication
+	buf := make([]byte, 262)
+	if _, err = io.ReadFull(conn, buf[:2]); err != nil {
+		return
+	}
+	if _, err = io.ReadFull(conn, buf[:buf[1]]); err != nil {
+		return
+	}
+	conn.Write([]byte{5, 0})
+
+	// request: version, command, reserved, address type
+	if _, err = io.ReadFull(conn, buf[:4]); err != nil {
+		return
+	}
+	var requested string
+	switch buf[3] {
+	case 1:
+		io.ReadFull(conn, buf[:4])
+		requested = net.IP(buf[:4]).String()
+	case 3:
+		io.ReadFull(conn, buf[:1])
+		n := int(buf[0])
+		io.ReadFull(conn, buf[:n])
+		requested = string(buf[:n])
+	default:
+		t.Errorf("unexpected socks5 address type %d.", buf[3])
+		return
+	}
+	io.ReadFull(conn, buf[:2])
+	host <- net.JoinHostPort(requested, strconv.Itoa(int(binary.BigEndian.Uint16(buf[:2]))))
+
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+	conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+	go io.Copy(upstream, conn)
+	io.Copy(conn, upstream)
+}
+
+func TestProxySOCKS5(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer ts.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	host := make(chan string, 1)
+	go serveSOCKS5(t, listener, ts.Listener.Addr().String(), host)
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{Proxy: http.ProxyURL(&url.URL{Scheme: "socks5", Host: listener.Addr().String()})},
+		Protocol:  ProtocolHTTP1,
+	}}
+
+	// the host only resolves at the proxy
+	response, err := client.Get("http://socks.test:8080/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	if requested := <-host; requested != "socks.test:8080" {
+		t.Errorf("socks5 proxy should get the host name and gets %s.", requested)
+	}
+	if string(body) != "socks.test:8080" {
+		t.Errorf("request should be sent through the proxy and is for %s.", body)
+	}
+}
+
+func TestProxyAddr(t *testing.T) {
+	for proxy, addr := range map[string]string{
+		"http://127.0.0.1":          "127.0.0.1:80",
+		"http://127.0.0.1:3128":     "127.0.0.1:3128",
+		"https://proxy.test":        "proxy.test:443",
+		"socks5://proxy.test":       "proxy.test:1080",
+		"http://[::1]":              "[::1]:80",
+		"socks4://proxy.test":       "proxy.test",
+		"http://user:pw@proxy.test": "proxy.test:80",
+	} {
+		proxyURL, _ := url.Parse(proxy)
+		if result := proxyAddr(proxyURL); result != addr {
+			t.Errorf("address of %s should be %s and is %s.", proxy, addr, result)
+		}
+	}
+}
+
+func TestProxyDefaultPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:80")
+	if err != nil {
+		t.Skipf("port 80 is not available, %v", err)
+	}
+	proxy := &httptest.Server{Listener: listener, Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.String()))
+	})}}
+	proxy.Start()
+	defer proxy.Close()
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{Proxy: http.ProxyURL(&url.URL{Scheme: "http", Host: "127.0.0.1"})},
+		Protocol:  ProtocolHTTP1,
+	}}
+	response, err := client.Get("http://proxied.test/path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if string(body) != "proxied http://proxied.test/path" {
+		t.Errorf("request should be sent to the proxy on port 80 and is %q.", body)
+	}
+}
@@ -0,0 +1,203 @@
+package curl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+const (
+	// how much of the request body is read ahead of libcurl
+	maxBufferedRequestBodySize = 1 << 20
+	requestBodyChunkSize       = 32 << 10
+)
+
+var errRequestBodyClosed = errors.New("curl: request body closed")
+
+// requestBody streams the body of a request to the read callback. The body
+// is read on its own goroutine so a slow body never blocks the multi loop,
+// the read callback pauses the transfer until data is available.
+type requestBody struct {
+	// -1 when unknown
+	length  int64
+	getBody func() (io.ReadCloser, error)
+	// resume continues the transfer after read paused it
+	resume func()
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	body   io.ReadCloser
+	buf    bytes.Buffer
+	eof    bool
+	err    error
+	paused bool
+	// bytes passed to libcurl since the last rewind
+	sent int64
+	// libcurl read the end of the body
+	finished bool
+	// bumped by every rewind and close, a pump of an older generation stops
+	generation int
+}
+
+func newRequestBody(body io.ReadCloser, length int64, getBody func() (io.ReadCloser, error), resume func()) *requestBody {
+	b := &requestBody{length: length, getBody: getBody, resume: resume, body: body}
+	b.cond = sync.NewCond(&b.mu)
+	go b.pump(body, 0, 0)
+	return b
+}
+
+// pump copies body into the buffer after discarding skip bytes
+func (b *requestBody) pump(body io.Reader, generation int, skip int64) {
+	if skip > 0 {
+		if _, err := io.CopyN(ioutil.Discard, body, skip); err != nil {
+			b.fill(generation, nil, err)
+			return
+		}
+	}
+
+	chunk := make([]byte, requestBodyChunkSize)
+	for {
+		b.mu.Lock()
+		for b.generation == generation && b.buf.Len() >= maxBufferedRequestBodySize {
+			b.cond.Wait()
+		}
+		current := b.generation == generation
+		b.mu.Unlock()
+		if !current {
+			return
+		}
+
+		n, err := body.Read(chunk)
+		if !b.fill(generation, chunk[:n], err) || err != nil {
+			return
+		}
+	}
+}
+
+// fill buffers data read by the pump, false means the pump is stale
+func (b *requestBody) fill(generation int, data []byte, err error) bool {
+	b.mu.Lock()
+	if b.generation != generation {
+		b.mu.Unlock()
+		return false
+	}
+
+	b.buf.Write(data)
+	if err == io.EOF {
+		b.eof = true
+	} else if err != nil {
+		b.err = err
+	}
+
+	resume := b.paused && (len(data) > 0 || err != nil)
+	if resume {
+		b.paused = false
+	}
+	b.mu.Unlock()
+
+	if resume {
+		b.resume()
+	}
+	return true
+}
+
+// read is the OPT_READFUNCTION of the transfer
+func (b *requestBody) read(p []byte) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.buf.Len() > 0:
+		n, _ := b.buf.Read(p)
+		b.sent += int64(n)
+		b.cond.Broadcast()
+		return n
+	case b.err != nil:
+		return libcurl.READFUNC_ABORT
+	case b.eof:
+		b.finished = true
+		return 0
+	default:
+		b.paused = true
+		return libcurl.READFUNC_PAUSE
+	}
+}
+
+// seek is the OPT_SEEKFUNCTION, libcurl rewinds the body to send it again,
+// e.g. for an auth retry or on a dead reused connection.
+func (b *requestBody) seek(offset int64, origin int) int {
+	if origin != io.SeekStart {
+		return libcurl.SEEKFUNC_CANTSEEK
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// nothing was sent yet, the buffer still holds the start of the body
+	if offset == 0 && b.sent == 0 {
+		return libcurl.SEEKFUNC_OK
+	}
+	if b.getBody == nil {
+		return libcurl.SEEKFUNC_CANTSEEK
+	}
+
+	body, err := b.getBody()
+	if err != nil {
+		return libcurl.SEEKFUNC_FAIL
+	}
+
+	b.closeBody()
+	b.body = body
+	b.buf = bytes.Buffer{}
+	b.eof = false
+	b.err = nil
+	b.sent = offset
+	b.finished = false
+	go b.pump(body, b.generation, offset)
+	return libcurl.SEEKFUNC_OK
+}
+
+// sentAll reports whether libcurl read the whole body
+func (b *requestBody) sentAll() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// libcurl stops reading a body of known length without waiting for EOF
+	return b.finished || (b.length >= 0 && b.sent >= b.length)
+}
+
+// close stops the pump and closes the body, it is called once the transfer is over.
+func (b *requestBody) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closeBody()
+	b.buf = bytes.Buffer{}
+	b.err = errRequestBodyClosed
+}
+
+func (b *requestBody) closeBody() {
+	b.generation++
+	b.cond.Broadcast()
+	if b.body != nil {
+		b.body.Close()
+		b.body = nil
+	}
+}
+
+// outgoingLength is the length of the request body like http.Transport
+// sees it, -1 when it is unknown.
+func outgoingLength(request *http.Request) int64 {
+	if request.Body == nil || request.Body == http.NoBody {
+		return 0
+	}
+	if request.ContentLength != 0 {
+		return request.ContentLength
+	}
+	return -1
+}
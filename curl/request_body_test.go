@@ -0,0 +1,232 @@
+package curl
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// readRequestBody calls read like libcurl does, waiting for resume on a pause
+func readRequestBody(t *testing.T, body *requestBody, resumed chan struct{}, size int) string {
+	var data []byte
+	buf := make([]byte, size)
+	for {
+		n := body.read(buf)
+		switch n {
+		case 0:
+			return string(data)
+		case libcurl.READFUNC_PAUSE:
+			select {
+			case <-resumed:
+			case <-time.After(5 * time.Second):
+				t.Fatal("paused request body is never resumed.")
+			}
+		case libcurl.READFUNC_ABORT:
+			t.Fatal("request body aborted.")
+		default:
+			data = append(data, buf[:n]...)
+		}
+	}
+}
+
+func TestRequestBodySeek(t *testing.T) {
+	const content = "hello request body"
+	getBody := func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(content)), nil
+	}
+
+	resumed := make(chan struct{}, 1)
+	resume := func() {
+		select {
+		case resumed <- struct{}{}:
+		default:
+		}
+	}
+
+	first, _ := getBody()
+	body := newRequestBody(first, int64(len(content)), getBody, resume)
+	defer body.close()
+
+	if data := readRequestBody(t, body, resumed, 4); data != content {
+		t.Errorf("body should be %q and is %q.", content, data)
+	}
+
+	if ret := body.seek(0, io.SeekStart); ret != libcurl.SEEKFUNC_OK {
+		t.Fatalf("seek should succeed and returns %d.", ret)
+	}
+	if data := readRequestBody(t, body, resumed, 4); data != content {
+		t.Errorf("rewound body should be %q and is %q.", content, data)
+	}
+
+	if ret := body.seek(6, io.SeekStart); ret != libcurl.SEEKFUNC_OK {
+		t.Fatalf("seek should succeed and returns %d.", ret)
+	}
+	if data := readRequestBody(t, body, resumed, 4); data != content[6:] {
+		t.Errorf("body after seek should be %q and is %q.", content[6:], data)
+	}
+
+	if ret := body.seek(0, io.SeekCurrent); ret != libcurl.SEEKFUNC_CANTSEEK {
+		t.Errorf("relative seek should not be possible and returns %d.", ret)
+	}
+}
+
+func TestRequestBodyCantSeek(t *testing.T) {
+	body := newRequestBody(ioutil.NopCloser(strings.NewReader("data")), -1, nil, func() {})
+	defer body.close()
+
+	if ret := body.seek(0, io.SeekStart); ret != libcurl.SEEKFUNC_OK {
+		t.Errorf("seek before reading should succeed and returns %d.", ret)
+	}
+
+	resumed := make(chan struct{})
+	close(resumed)
+	readRequestBody(t, body, resumed, 4)
+	if ret := body.seek(0, io.SeekStart); ret != libcurl.SEEKFUNC_CANTSEEK {
+		t.Errorf("seek without GetBody should not be possible and returns %d.", ret)
+	}
+}
+
+func TestTransportRequestMethods(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		contentType := "none"
+		if values, ok := r.Header["Content-Type"]; ok {
+			contentType = values[0]
+		}
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Body", string(body))
+		w.Header().Set("X-Content-Type", contentType)
+		w.Header().Set("X-Transfer-Encoding", strings.Join(r.TransferEncoding, ","))
+		w.Write([]byte("response"))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	for _, test := range []struct {
+		method      string
+		body        string
+		unknown     bool
+		contentType string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodHead},
+		{method: http.MethodOptions},
+		{method: http.MethodPost},
+		{method: http.MethodPost, body: "{}"},
+		{method: http.MethodPost, body: "{}", contentType: "application/json"},
+		{method: http.MethodPost, body: "unknown", unknown: true},
+		{method: http.MethodPut, body: "put"},
+		{method: http.MethodPatch, body: "patch"},
+		{method: http.MethodPatch, body: "unknown", unknown: true},
+		{method: http.MethodDelete, body: "delete"},
+	} {
+		var body io.Reader
+		if test.body != "" {
+			body = strings.NewReader(test.body)
+		}
+		request, _ := http.NewRequest(test.method, ts.URL, body)
+		if test.unknown {
+			// a body of unknown length is sent chunked
+			request.Body = ioutil.NopCloser(body)
+			request.ContentLength = -1
+		}
+		if test.contentType != "" {
+			request.Header.Set("Content-Type", test.contentType)
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatalf("%s %q: %v", test.method, test.body, err)
+		}
+		data, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+
+		name := test.method + " " + test.body
+		if method := response.Header.Get("X-Method"); method != test.method {
+			t.Errorf("%s: method should be sent and is %s.", name, method)
+		}
+		if received := response.Header.Get("X-Body"); received != test.body {
+			t.Errorf("%s: body should be received and is %q.", name, received)
+		}
+		contentType := test.contentType
+		if contentType == "" {
+			contentType = "none"
+		}
+		if received := response.Header.Get("X-Content-Type"); received != contentType {
+			t.Errorf("%s: Content-Type should be %s and is %s.", name, contentType, received)
+		}
+		if chunked := response.Header.Get("X-Transfer-Encoding") == "chunked"; chunked != test.unknown {
+			t.Errorf("%s: body should be sent chunked only for an unknown length, chunked %v.", name, chunked)
+		}
+		// HEAD sets OPT_NOBODY, the transfer does not wait for a body
+		expected := "response"
+		if test.method == http.MethodHead {
+			expected = ""
+		}
+		if string(data) != expected {
+			t.Errorf("%s: response body is %q.", name, data)
+		}
+	}
+}
+
+func TestTransportRequestBodyRewind(t *testing.T) {
+	var mu sync.Mutex
+	dropped := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		drop := r.URL.Path == "/retry" && !dropped
+		dropped = dropped || drop
+		mu.Unlock()
+		if drop {
+			// libcurl sends the request again on a new connection when a
+			// reused one is closed without a response, it rewinds the body
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write(body)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	response, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	const content = "rewound body"
+	request, _ := http.NewRequest(http.MethodPost, ts.URL+"/retry", strings.NewReader(content))
+	getBody := request.GetBody
+	var getBodyCalls int32
+	request.GetBody = func() (io.ReadCloser, error) {
+		atomic.AddInt32(&getBodyCalls, 1)
+		return getBody()
+	}
+
+	response, err = client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	if string(data) != content {
+		t.Errorf("body sent again should be %q and is %q.", content, data)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls := atomic.LoadInt32(&getBodyCalls); !dropped || calls != 1 {
+		t.Errorf("retry should get the body again once and gets it %d times.", calls)
+	}
+}
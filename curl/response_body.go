@@ -0,0 +1,138 @@
+package curl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+const (
+	// the write callback pauses the transfer once this many bytes are buffered
+	maxBufferedBodySize = 1 << 20
+	// a paused transfer is resumed once the reader drains the buffer below this
+	resumeBufferedBodySize = maxBufferedBodySize / 2
+)
+
+var errReadOnClosedBody = errors.New("curl: read on closed response body")
+
+// responseBody streams the body of a running transfer to the caller.
+// write and finish are called on the multi loop goroutine, Read and Close
+// from the caller's goroutine.
+type responseBody struct {
+	// resume continues the transfer after write paused it
+	resume func()
+	// cancel stops the transfer if it is still running
+	cancel func(error)
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	buf    bytes.Buffer
+	paused bool
+	done   bool
+	err    error
+	// set once the caller gives up on the transfer, by Close or a cancelled context
+	abortErr error
+}
+
+func newResponseBody(resume func(), cancel func(error)) *responseBody {
+	body := &responseBody{resume: resume, cancel: cancel}
+	body.cond = sync.NewCond(&body.mu)
+	return body
+}
+
+// write buffers data from OPT_WRITEFUNCTION, false pauses the transfer and
+// libcurl delivers the same data again once it is resumed.
+func (b *responseBody) write(data []byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.abortErr != nil {
+		// the transfer is being cancelled
+		return true
+	}
+
+	if b.buf.Len() > 0 && b.buf.Len()+len(data) > maxBufferedBodySize {
+		b.paused = true
+		return false
+	}
+
+	b.buf.Write(data)
+	b.cond.Broadcast()
+	return true
+}
+
+// finish is called once the transfer is over, err is its result.
+func (b *responseBody) finish(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.done = true
+	b.err = err
+	b.cond.Broadcast()
+}
+
+// abort makes Read fail with err, a transfer which is still running is cancelled.
+func (b *responseBody) abort(err error) {
+	b.mu.Lock()
+	if b.abortErr != nil {
+		b.mu.Unlock()
+		return
+	}
+
+	b.abortErr = err
+	b.buf = bytes.Buffer{}
+	done := b.done
+	b.cond.Broadcast()
+	b.mu.Unlock()
+
+	if !done {
+		b.cancel(err)
+	}
+}
+
+// failure returns the reason the transfer failed, if any.
+func (b *responseBody) failure() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.abortErr != nil {
+		return b.abortErr
+	}
+	return b.err
+}
+
+func (b *responseBody) Read(p []byte) (n int, err error) {
+	b.mu.Lock()
+	for b.buf.Len() == 0 && !b.done && b.abortErr == nil {
+		b.cond.Wait()
+	}
+
+	resume := false
+	switch {
+	case b.abortErr != nil:
+		err = b.abortErr
+	case b.buf.Len() > 0:
+		n, err = b.buf.Read(p)
+		if b.paused && b.buf.Len() <= resumeBufferedBodySize {
+			b.paused = false
+			resume = true
+		}
+	case b.err != nil:
+		err = b.err
+	default:
+		err = io.EOF
+	}
+	b.mu.Unlock()
+
+	if resume {
+		b.resume()
+	}
+	return
+}
+
+// Close drops the buffered data, a transfer which is still running is cancelled.
+func (b *responseBody) Close() error {
+	b.abort(errReadOnClosedBody)
+	return nil
+}
@@ -0,0 +1,114 @@
+package curl
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseBodyStreaming(t *testing.T) {
+	next := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		<-next
+		w.Write([]byte("second"))
+	}))
+	defer ts.Close()
+	defer close(next)
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	response, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	// the first chunk is read while the server holds back the rest
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(response.Body, buf); err != nil || string(buf) != "first" {
+		t.Fatalf("first chunk should be read before the body is complete, %q %v.", buf, err)
+	}
+
+	next <- struct{}{}
+	rest, err := ioutil.ReadAll(response.Body)
+	if err != nil || string(rest) != "second" {
+		t.Errorf("rest of the body should be %q and is %q, %v.", "second", rest, err)
+	}
+}
+
+func TestResponseBodyBackpressure(t *testing.T) {
+	const size = 64 << 20
+	chunk := bytes.Repeat([]byte("a"), 32<<10)
+	written := int64(0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for atomic.LoadInt64(&written) < size {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+			atomic.AddInt64(&written, int64(len(chunk)))
+		}
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	response, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	// the paused transfer stops reading, so the server blocks once the
+	// socket buffers are full
+	time.Sleep(500 * time.Millisecond)
+	if n := atomic.LoadInt64(&written); n >= size/2 {
+		t.Fatalf("server should be blocked by an unread body and wrote %d bytes.", n)
+	}
+
+	n, err := io.Copy(ioutil.Discard, response.Body)
+	if err != nil || n != size {
+		t.Errorf("body should have %d bytes and has %d, %v.", size, n, err)
+	}
+}
+
+func TestResponseBodyCloseBeforeEOF(t *testing.T) {
+	handlerDone := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(handlerDone)
+		chunk := bytes.Repeat([]byte("a"), 32<<10)
+		for {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	response, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 1024)
+	if _, err := io.ReadFull(response.Body, buf); err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if _, err := response.Body.Read(buf); err != errReadOnClosedBody {
+		t.Errorf("read after Close should fail with %v and is %v.", errReadOnClosedBody, err)
+	}
+
+	// closing the body aborts the transfer and its connection
+	select {
+	case <-handlerDone:
+	case <-time.After(5 * time.Second):
+		t.Error("transfer should be aborted by Close.")
+	}
+}
@@ -0,0 +1,339 @@
+package curl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+var errUnknownRootCAs = errors.New("curl: tls.Config.RootCAs must be created by curl.NewCertPool")
+
+// openssl names of the TLS 1.2 and earlier cipher suites
+var cipherSuiteNames = map[uint16]string{
+	tls.TLS_RSA_WITH_RC4_128_SHA:                      "RC4-SHA",
+	tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA:                 "DES-CBC3-SHA",
+	tls.TLS_RSA_WITH_AES_128_CBC_SHA:                  "AES128-SHA",
+	tls.TLS_RSA_WITH_AES_256_CBC_SHA:                  "AES256-SHA",
+	tls.TLS_RSA_WITH_AES_128_CBC_SHA256:               "AES128-SHA256",
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256:               "AES128-GCM-SHA256",
+	tls.TLS_RSA_WITH_AES_256_GCM_SHA384:               "AES256-GCM-SHA384",
+	tls.TLS_ECDHE_ECDSA_WITH_RC4_128_SHA:              "ECDHE-ECDSA-RC4-SHA",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA:          "ECDHE-ECDSA-AES128-SHA",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA:          "ECDHE-ECDSA-AES256-SHA",
+	tls.TLS_ECDHE_RSA_WITH_RC4_128_SHA:                "ECDHE-RSA-RC4-SHA",
+	tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA:           "ECDHE-RSA-DES-CBC3-SHA",
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA:            "ECDHE-RSA-AES128-SHA",
+	tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA:            "ECDHE-RSA-AES256-SHA",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256:       "ECDHE-ECDSA-AES128-SHA256",
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256:         "ECDHE-RSA-AES128-SHA256",
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:         "ECDHE-RSA-AES128-GCM-SHA256",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256:       "ECDHE-ECDSA-AES128-GCM-SHA256",
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:         "ECDHE-RSA-AES256-GCM-SHA384",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384:       "ECDHE-ECDSA-AES256-GCM-SHA384",
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256:   "ECDHE-RSA-CHACHA20-POLY1305",
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256: "ECDHE-ECDSA-CHACHA20-POLY1305",
+}
+
+// like crypto/tls, the TLS 1.3 cipher suites are not configurable and
+// ignored in tls.Config.CipherSuites
+var tls13CipherSuites = map[uint16]bool{
+	tls.TLS_AES_128_GCM_SHA256:       true,
+	tls.TLS_AES_256_GCM_SHA384:       true,
+	tls.TLS_CHACHA20_POLY1305_SHA256: true,
+}
+
+// cipherSuiteList is the OPT_SSL_CIPHER_LIST of suites, empty for the
+// default of libcurl
+func cipherSuiteList(suites []uint16) (string, error) {
+	names := make([]string, 0, len(suites))
+	for _, id := range suites {
+		if tls13CipherSuites[id] {
+			continue
+		}
+		name, ok := cipherSuiteNames[id]
+		if !ok {
+			return "", fmt.Errorf("curl: unsupported cipher suite %s", tls.CipherSuiteName(id))
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ":"), nil
+}
+
+var sslVersions = map[uint16]int{
+	tls.VersionTLS10: libcurl.SSLVERSION_TLSv1_0,
+	tls.VersionTLS11: libcurl.SSLVERSION_TLSv1_1,
+	tls.VersionTLS12: libcurl.SSLVERSION_TLSv1_2,
+	tls.VersionTLS13: libcurl.SSLVERSION_TLSv1_3,
+}
+
+var sslMaxVersions = map[uint16]int{
+	tls.VersionTLS10: libcurl.SSLVERSION_MAX_TLSv1_0,
+	tls.VersionTLS11: libcurl.SSLVERSION_MAX_TLSv1_1,
+	tls.VersionTLS12: libcurl.SSLVERSION_MAX_TLSv1_2,
+	tls.VersionTLS13: libcurl.SSLVERSION_MAX_TLSv1_3,
+}
+
+// caBundle is the PEM file of a pool created by NewCertPool
+type caBundle struct {
+	pem []byte
+
+	mu       sync.Mutex
+	path     string
+	err      error
+	released bool
+}
+
+var (
+	caBundleLock sync.Mutex
+	caBundles    = make(map[uintptr]*caBundle)
+)
+
+// NewCertPool returns a pool of the PEM encoded certificates in pemCerts.
+// libcurl reads CA certificates from a file and an x509.CertPool can't be
+// listed, so tls.Config.RootCAs of a Transport must be created by NewCertPool.
+// The file is written when the pool is first used, ReleaseCertPool removes it.
+func NewCertPool(pemCerts []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, errors.New("curl: no certificates found in PEM data")
+	}
+
+	key := uintptr(unsafe.Pointer(pool))
+	bundle := &caBundle{pem: append([]byte(nil), pemCerts...)}
+	caBundleLock.Lock()
+	caBundles[key] = bundle
+	caBundleLock.Unlock()
+
+	// in case the pool is never released
+	runtime.SetFinalizer(pool, func(pool *x509.CertPool) {
+		ReleaseCertPool(pool)
+	})
+	return pool, nil
+}
+
+// ReleaseCertPool removes the CA file of a pool created by NewCertPool, call
+// it once no request uses the pool anymore. Later requests with the pool
+// fail like with a pool not created by NewCertPool. A pool which is not
+// released has its file removed when it is garbage collected.
+func ReleaseCertPool(pool *x509.CertPool) error {
+	key := uintptr(unsafe.Pointer(pool))
+	caBundleLock.Lock()
+	bundle := caBundles[key]
+	delete(caBundles, key)
+	caBundleLock.Unlock()
+	if bundle == nil {
+		return nil
+	}
+
+	runtime.SetFinalizer(pool, nil)
+	return bundle.release()
+}
+
+// file writes the CA file on the first call and returns its path
+func (bundle *caBundle) file() (string, error) {
+	bundle.mu.Lock()
+	defer bundle.mu.Unlock()
+
+	if bundle.released {
+		return "", errUnknownRootCAs
+	}
+	if bundle.path != "" || bundle.err != nil {
+		return bundle.path, bundle.err
+	}
+
+	file, err := ioutil.TempFile("", "go-curl-ca-*.pem")
+	if err != nil {
+		bundle.err = err
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err = file.Write(bundle.pem); err != nil {
+		os.Remove(file.Name())
+		bundle.err = err
+		return "", err
+	}
+	bundle.path = file.Name()
+	return bundle.path, nil
+}
+
+func (bundle *caBundle) release() error {
+	bundle.mu.Lock()
+	defer bundle.mu.Unlock()
+
+	bundle.released = true
+	if bundle.path == "" {
+		return nil
+	}
+	path := bundle.path
+	bundle.path = ""
+	return os.Remove(path)
+}
+
+// caBundlePath returns the path of the CA file for a pool created by NewCertPool
+func caBundlePath(pool *x509.CertPool) (string, error) {
+	caBundleLock.Lock()
+	bundle := caBundles[uintptr(unsafe.Pointer(pool))]
+	caBundleLock.Unlock()
+	if bundle == nil {
+		return "", errUnknownRootCAs
+	}
+	return bundle.file()
+}
+
+// setupTLS maps config onto the TLS options of easy, peers are verified
+// unless config.InsecureSkipVerify is set.
+func setupTLS(easy *libcurl.CURL, config *tls.Config) (err error) {
+	if config == nil {
+		return nil
+	}
+
+	if config.InsecureSkipVerify {
+		err = easy.Setopt(libcurl.OPT_SSL_VERIFYPEER, 0)
+		if err != nil {
+			return
+		}
+
+		err = easy.Setopt(libcurl.OPT_SSL_VERIFYHOST, 0)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.RootCAs != nil {
+		var caPath string
+		caPath, err = caBundlePath(config.RootCAs)
+		if err != nil {
+			return
+		}
+
+		err = easy.Setopt(libcurl.OPT_CAINFO, caPath)
+		if err != nil {
+			return
+		}
+	}
+
+	if config.MinVersion != 0 || config.MaxVersion != 0 {
+		version := libcurl.SSLVERSION_DEFAULT
+		if config.MinVersion != 0 {
+			minVersion, ok := sslVersions[config.MinVersion]
+			if !ok {
+				return fmt.Errorf("curl: unsupported TLS version %#x", config.MinVersion)
+			}
+			version |= minVersion
+		}
+		if config.MaxVersion != 0 {
+			maxVersion, ok := sslMaxVersions[config.MaxVersion]
+			if !ok {
+				return fmt.Errorf("curl: unsupported TLS version %#x", config.MaxVersion)
+			}
+			version |= maxVersion
+		}
+
+		err = easy.Setopt(libcurl.OPT_SSLVERSION, version)
+		if err != nil {
+			return
+		}
+	}
+
+	cipherList, err := cipherSuiteList(config.CipherSuites)
+	if err != nil {
+		return
+	}
+	if cipherList != "" {
+		err = easy.Setopt(libcurl.OPT_SSL_CIPHER_LIST, cipherList)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(config.Certificates) > 0 {
+		// libcurl presents a single client certificate
+		err = easy.SetClientCertificate(&config.Certificates[0])
+		if err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+// serverNameURL returns the URL to request when config.ServerName differs
+// from the host of u. libcurl takes the SNI and the name it verifies from
+// the URL, so the server name is requested and connectTo, an OPT_CONNECT_TO
+// entry, connects it to the real host.
+func serverNameURL(u *url.URL, config *tls.Config) (requestURL *url.URL, connectTo string) {
+	if config == nil || config.ServerName == "" || u.Scheme != "https" || config.ServerName == u.Hostname() {
+		return u, ""
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	requestURL = new(url.URL)
+	*requestURL = *u
+	requestURL.Host = net.JoinHostPort(config.ServerName, port)
+	return requestURL, connectToHost(config.ServerName) + ":" + port + ":" + connectToHost(u.Hostname()) + ":" + port
+}
+
+// connectToHost brackets IPv6 addresses in OPT_CONNECT_TO and OPT_RESOLVE entries
+func connectToHost(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// connectionState builds the TLS state of a https transfer from libcurl,
+// it must be called while the transfer runs.
+func connectionState(easy *libcurl.CURL, serverName string) *tls.ConnectionState {
+	state := &tls.ConnectionState{
+		HandshakeComplete: true,
+		ServerName:        serverName,
+	}
+
+	if info, _ := easy.TLSInfo(); info != nil {
+		state.Version = info.Version
+		state.CipherSuite = info.CipherSuite
+		state.NegotiatedProtocol = info.ALPN
+	} else if versionI, _ := easy.Getinfo(libcurl.INFO_HTTP_VERSION); versionI == libcurl.HTTP_VERSION_3 {
+		// QUIC always runs TLS 1.3, the TLS session is owned by quiche
+		state.Version = tls.VersionTLS13
+		state.NegotiatedProtocol = "h3"
+	}
+
+	// each certificate of OPT_CERTINFO has a "Cert:" entry with its PEM
+	certInfoI, _ := easy.Getinfo(libcurl.INFO_CERTINFO)
+	certInfo, _ := certInfoI.([][]string)
+	for _, entries := range certInfo {
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry, "Cert:") {
+				continue
+			}
+
+			block, _ := pem.Decode([]byte(strings.TrimPrefix(entry, "Cert:")))
+			if block == nil {
+				continue
+			}
+
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				state.PeerCertificates = append(state.PeerCertificates, cert)
+			}
+		}
+	}
+	return state
+}
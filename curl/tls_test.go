@@ -0,0 +1,149 @@
+package curl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+func TestServerNameURL(t *testing.T) {
+	u, _ := url.Parse("https://127.0.0.1:8443/path?q=1")
+
+	requestURL, connectTo := serverNameURL(u, &tls.Config{ServerName: "example.com"})
+	if expected := "https://example.com:8443/path?q=1"; requestURL.String() != expected {
+		t.Errorf("url should be %q and is %q.", expected, requestURL)
+	}
+	if expected := "example.com:8443:127.0.0.1:8443"; connectTo != expected {
+		t.Errorf("connect to should be %q and is %q.", expected, connectTo)
+	}
+
+	u, _ = url.Parse("https://[::1]/")
+	_, connectTo = serverNameURL(u, &tls.Config{ServerName: "example.com"})
+	if expected := "example.com:443:[::1]:443"; connectTo != expected {
+		t.Errorf("connect to should be %q and is %q.", expected, connectTo)
+	}
+
+	u, _ = url.Parse("https://example.com/")
+	if requestURL, connectTo = serverNameURL(u, &tls.Config{ServerName: "example.com"}); requestURL != u || connectTo != "" {
+		t.Errorf("url should not change and is %q, %q.", requestURL, connectTo)
+	}
+}
+
+func TestCipherSuiteList(t *testing.T) {
+	for _, test := range []struct {
+		suites []uint16
+		list   string
+	}{
+		{suites: nil, list: ""},
+		{suites: []uint16{tls.TLS_AES_128_GCM_SHA256, tls.TLS_CHACHA20_POLY1305_SHA256}, list: ""},
+		{
+			suites: []uint16{tls.TLS_AES_256_GCM_SHA384, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+			list:   "ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384",
+		},
+	} {
+		if list, err := cipherSuiteList(test.suites); list != test.list || err != nil {
+			t.Errorf("cipher list of %v should be %q and is %q, %v.", test.suites, test.list, list, err)
+		}
+	}
+
+	if _, err := cipherSuiteList([]uint16{0xffff}); err == nil {
+		t.Error("unknown cipher suite should not be supported.")
+	}
+
+	// a config http.Transport accepts is accepted
+	if err := globalInit(); err != nil {
+		t.Fatal(err)
+	}
+	easy := libcurl.EasyInit()
+	defer cleanupEasy(easy)
+	if err := setupTLS(easy, &tls.Config{CipherSuites: []uint16{tls.TLS_AES_128_GCM_SHA256}}); err != nil {
+		t.Errorf("TLS 1.3 cipher suites should be ignored and are %v.", err)
+	}
+}
+
+func TestCABundlePath(t *testing.T) {
+	if _, err := caBundlePath(x509.NewCertPool()); err != errUnknownRootCAs {
+		t.Errorf("error should be %v and is %v.", errUnknownRootCAs, err)
+	}
+
+	ts := httptest.NewTLSServer(nil)
+	defer ts.Close()
+
+	certPEM, _, err := libcurl.CertificatePEM(&tls.Certificate{Certificate: [][]byte{ts.Certificate().Raw}, PrivateKey: ts.TLS.Certificates[0].PrivateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err := NewCertPool(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := caBundlePath(pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(certPEM) {
+		t.Errorf("CA file should contain %q and is %q.", certPEM, content)
+	}
+
+	if err := ReleaseCertPool(pool); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("CA file should be removed by ReleaseCertPool, %v.", err)
+	}
+	if _, err := caBundlePath(pool); err != errUnknownRootCAs {
+		t.Errorf("released pool should fail with %v and is %v.", errUnknownRootCAs, err)
+	}
+}
+
+func TestResponseTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	pool, err := NewCertPool(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ReleaseCertPool(pool)
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		Protocol:  ProtocolHTTP1,
+	}}
+	response, err := client.Get(ts.URL)
+	if errors.Is(err, libcurl.CurlError(libcurl.E_SSL_CONNECT_ERROR)) {
+		t.Skip("libcurl can not connect to the TLS test server:", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	state := response.TLS
+	if state == nil {
+		t.Fatal("response of a https request should have TLS.")
+	}
+	if !state.HandshakeComplete || state.Version == 0 || state.ServerName != "127.0.0.1" {
+		t.Errorf("TLS should have the handshake of 127.0.0.1 and is %+v.", state)
+	}
+	if len(state.PeerCertificates) == 0 || !state.PeerCertificates[0].Equal(ts.Certificate()) {
+		t.Errorf("peer certificate should be the one of the server and is %v.", state.PeerCertificates)
+	}
+}
@@ -0,0 +1,357 @@
+package curl
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// clientTrace fires the httptrace.ClientTrace hooks of a request. libcurl
+// has no events for the phases of a transfer, so its timings are polled on
+// the multi loop and a hook fires once the timing of its phase is set.
+// Except getConn, run and close, all methods are called on the loop
+// goroutine. The hooks are queued there and run on the goroutine of
+// RoundTrip, so a slow hook does not hold up the other transfers. Hooks
+// of phases which end after RoundTrip returned are dropped.
+type clientTrace struct {
+	trace   *httptrace.ClientTrace
+	easy    *libcurl.CURL
+	url     *url.URL
+	network string
+	https   bool
+	quic    bool
+	// the request body, nil when there is none
+	body *requestBody
+
+	mu     sync.Mutex
+	hooks  []func()
+	closed bool
+	// signaled when hooks are queued
+	queued chan struct{}
+
+	polled, dnsStarted, dnsDone          bool
+	newConn, connectStarted, connectDone bool
+	tlsStarted, tlsDone, gotConn         bool
+	wroteRequest, gotFirstResponseByte   bool
+}
+
+// newClientTrace returns nil when ctx has no httptrace.ClientTrace
+func newClientTrace(ctx context.Context, easy *libcurl.CURL, u *url.URL, quic bool) *clientTrace {
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace == nil {
+		return nil
+	}
+
+	network := "tcp"
+	if quic {
+		network = "udp"
+	}
+	return &clientTrace{
+		trace:   trace,
+		easy:    easy,
+		url:     u,
+		network: network,
+		https:   u.Scheme == "https",
+		quic:    quic,
+		queued:  make(chan struct{}, 1),
+	}
+}
+
+// fire queues hook to run on the goroutine of RoundTrip
+func (t *clientTrace) fire(hook func()) {
+	t.mu.Lock()
+	if !t.closed {
+		t.hooks = append(t.hooks, hook)
+	}
+	t.mu.Unlock()
+
+	select {
+	case t.queued <- struct{}{}:
+	default:
+	}
+}
+
+// run calls the queued hooks in order
+func (t *clientTrace) run() {
+	t.mu.Lock()
+	hooks := t.hooks
+	t.hooks = nil
+	t.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// close runs the queued hooks, the later ones are dropped
+func (t *clientTrace) close() {
+	t.run()
+	t.mu.Lock()
+	t.closed = true
+	t.hooks = nil
+	t.mu.Unlock()
+}
+
+func (t *clientTrace) hostPort() string {
+	return net.JoinHostPort(t.url.Hostname(), originPort(t.url))
+}
+
+// getConn is called before the transfer starts
+func (t *clientTrace) getConn() {
+	if t.trace.GetConn != nil {
+		t.trace.GetConn(t.hostPort())
+	}
+}
+
+// poll fires the hooks of the phases libcurl finished since the last call
+func (t *clientTrace) poll() {
+	nameLookupTime := t.timing(libcurl.INFO_NAMELOOKUP_TIME)
+	connectTime := t.timing(libcurl.INFO_CONNECT_TIME)
+	appConnectTime := t.timing(libcurl.INFO_APPCONNECT_TIME)
+	preTransferTime := t.timing(libcurl.INFO_PRETRANSFER_TIME)
+	numConnectsI, _ := t.easy.Getinfo(libcurl.INFO_NUM_CONNECTS)
+	numConnects, _ := numConnectsI.(int)
+
+	if !t.polled {
+		t.polled = true
+		// a reused connection is ready on the first perform, a new one may
+		// be connected already. Like http.Transport no DNS is traced for IP
+		// literals.
+		if (numConnects > 0 || preTransferTime == 0) && net.ParseIP(t.url.Hostname()) == nil {
+			t.dnsStarted = true
+			if t.trace.DNSStart != nil {
+				info := httptrace.DNSStartInfo{Host: t.url.Hostname()}
+				t.fire(func() { t.trace.DNSStart(info) })
+			}
+		}
+	}
+
+	// libcurl counts a connection once it opens its socket
+	t.newConn = t.newConn || numConnects > 0
+
+	if t.dnsStarted && !t.dnsDone && (nameLookupTime > 0 || t.newConn || preTransferTime > 0) {
+		t.finishDNS(nil)
+	}
+
+	if t.newConn && !t.connectStarted {
+		t.startConnect()
+		// the TLS handshake of QUIC is part of its connect
+		if t.quic {
+			t.startTLS()
+		}
+	}
+
+	if t.connectStarted && !t.connectDone && connectTime > 0 {
+		t.finishConnect(nil)
+		if t.https && !t.quic {
+			t.startTLS()
+		}
+	}
+
+	if t.tlsStarted && !t.tlsDone && appConnectTime > 0 {
+		t.finishTLS(nil)
+	}
+
+	if !t.gotConn && preTransferTime > 0 {
+		t.gotConn = true
+		if t.trace.GotConn != nil {
+			info := httptrace.GotConnInfo{
+				Conn:   t.conn(),
+				Reused: !t.newConn,
+			}
+			t.fire(func() { t.trace.GotConn(info) })
+		}
+	}
+
+	if t.gotConn && !t.wroteRequest && (t.body == nil || t.body.sentAll()) {
+		t.finishRequest(nil)
+	}
+}
+
+// firstResponseByte is called with the first header line
+func (t *clientTrace) firstResponseByte() {
+	t.poll()
+	if t.gotFirstResponseByte {
+		return
+	}
+
+	t.gotFirstResponseByte = true
+	if t.trace.GotFirstResponseByte != nil {
+		t.fire(t.trace.GotFirstResponseByte)
+	}
+}
+
+// informational is called with the header of a 1xx response
+func (t *clientTrace) informational(code int, header http.Header) {
+	if code == http.StatusContinue && t.trace.Got100Continue != nil {
+		t.fire(t.trace.Got100Continue)
+	}
+	if t.trace.Got1xxResponse != nil {
+		header = header.Clone()
+		t.fire(func() { t.trace.Got1xxResponse(code, textproto.MIMEHeader(header)) })
+	}
+}
+
+// finish is called when the transfer is over, the phases which did not
+// finish get err.
+func (t *clientTrace) finish(err error) {
+	t.poll()
+	if err == nil {
+		return
+	}
+
+	if t.dnsStarted && !t.dnsDone {
+		t.finishDNS(err)
+		return
+	}
+	// libcurl does not count a connection which failed to open
+	if !t.gotConn && !t.connectStarted {
+		t.startConnect()
+	}
+	if t.connectStarted && !t.connectDone {
+		t.finishConnect(err)
+	}
+	if t.tlsStarted && !t.tlsDone {
+		t.finishTLS(err)
+	}
+	if t.gotConn && !t.wroteRequest {
+		t.finishRequest(err)
+	}
+}
+
+func (t *clientTrace) finishDNS(err error) {
+	t.dnsDone = true
+	if t.trace.DNSDone == nil {
+		return
+	}
+
+	info := httptrace.DNSDoneInfo{Err: err}
+	if ip := net.ParseIP(t.primaryIP()); ip != nil {
+		info.Addrs = []net.IPAddr{{IP: ip}}
+	}
+	t.fire(func() { t.trace.DNSDone(info) })
+}
+
+func (t *clientTrace) startConnect() {
+	t.connectStarted = true
+	if t.trace.ConnectStart != nil {
+		network, addr := t.network, t.remoteAddr()
+		t.fire(func() { t.trace.ConnectStart(network, addr) })
+	}
+}
+
+func (t *clientTrace) finishConnect(err error) {
+	t.connectDone = true
+	if t.trace.ConnectDone != nil {
+		network, addr := t.network, t.remoteAddr()
+		t.fire(func() { t.trace.ConnectDone(network, addr, err) })
+	}
+}
+
+func (t *clientTrace) startTLS() {
+	t.tlsStarted = true
+	if t.trace.TLSHandshakeStart != nil {
+		t.fire(t.trace.TLSHandshakeStart)
+	}
+}
+
+func (t *clientTrace) finishTLS(err error) {
+	t.tlsDone = true
+	if t.trace.TLSHandshakeDone == nil {
+		return
+	}
+
+	state := tls.ConnectionState{}
+	if err == nil {
+		state = *connectionState(t.easy, t.url.Hostname())
+	}
+	t.fire(func() { t.trace.TLSHandshakeDone(state, err) })
+}
+
+func (t *clientTrace) finishRequest(err error) {
+	t.wroteRequest = true
+	if t.trace.WroteHeaders != nil {
+		t.fire(t.trace.WroteHeaders)
+	}
+	if t.trace.WroteRequest != nil {
+		info := httptrace.WroteRequestInfo{Err: err}
+		t.fire(func() { t.trace.WroteRequest(info) })
+	}
+}
+
+// timing returns a INFO_*_TIME of the transfer, 0 until its phase is over
+func (t *clientTrace) timing(info libcurl.CurlInfo) float64 {
+	timeI, _ := t.easy.Getinfo(info)
+	seconds, _ := timeI.(float64)
+	return seconds
+}
+
+func (t *clientTrace) primaryIP() string {
+	ipI, _ := t.easy.Getinfo(libcurl.INFO_PRIMARY_IP)
+	ip, _ := ipI.(string)
+	return ip
+}
+
+// remoteAddr is the address of the connection, the host of the URL until
+// libcurl knows the address.
+func (t *clientTrace) remoteAddr() string {
+	ip := t.primaryIP()
+	portI, _ := t.easy.Getinfo(libcurl.INFO_PRIMARY_PORT)
+	if port, _ := portI.(int); ip != "" && port > 0 {
+		return net.JoinHostPort(ip, strconv.Itoa(port))
+	}
+	return t.hostPort()
+}
+
+// conn describes the connection of the transfer for GotConnInfo
+func (t *clientTrace) conn() net.Conn {
+	localIPI, _ := t.easy.Getinfo(libcurl.INFO_LOCAL_IP)
+	localIP, _ := localIPI.(string)
+	localPortI, _ := t.easy.Getinfo(libcurl.INFO_LOCAL_PORT)
+	localPort, _ := localPortI.(int)
+	remotePortI, _ := t.easy.Getinfo(libcurl.INFO_PRIMARY_PORT)
+	remotePort, _ := remotePortI.(int)
+	// libcurl reports -1 for the local port of a reused connection
+	if localPort < 0 {
+		localPort = 0
+	}
+
+	return &traceConn{
+		local:  t.addr(net.ParseIP(localIP), localPort),
+		remote: t.addr(net.ParseIP(t.primaryIP()), remotePort),
+	}
+}
+
+func (t *clientTrace) addr(ip net.IP, port int) net.Addr {
+	if t.quic {
+		return &net.UDPAddr{IP: ip, Port: port}
+	}
+	return &net.TCPAddr{IP: ip, Port: port}
+}
+
+var errTraceConn = errors.New("curl: the connection is owned by libcurl")
+
+// traceConn stands in for the connection libcurl owns, only its addresses
+// are usable.
+type traceConn struct {
+	local, remote net.Addr
+}
+
+func (c *traceConn) Read(b []byte) (int, error)         { return 0, errTraceConn }
+func (c *traceConn) Write(b []byte) (int, error)        { return 0, errTraceConn }
+func (c *traceConn) Close() error                       { return errTraceConn }
+func (c *traceConn) LocalAddr() net.Addr                { return c.local }
+func (c *traceConn) RemoteAddr() net.Addr               { return c.remote }
+func (c *traceConn) SetDeadline(t time.Time) error      { return errTraceConn }
+func (c *traceConn) SetReadDeadline(t time.Time) error  { return errTraceConn }
+func (c *traceConn) SetWriteDeadline(t time.Time) error { return errTraceConn }
@@ -0,0 +1,73 @@
+package curl
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestClientTraceOrder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("body"))
+	}))
+	defer ts.Close()
+
+	// a host name, so the lookup is traced
+	u, _ := url.Parse(ts.URL)
+	u.Host = "localhost:" + u.Port()
+
+	// the hooks run on the goroutine of RoundTrip, they need no lock
+	var hooks []string
+	reused := false
+	trace := &httptrace.ClientTrace{
+		GetConn:  func(string) { hooks = append(hooks, "GetConn") },
+		DNSStart: func(httptrace.DNSStartInfo) { hooks = append(hooks, "DNSStart") },
+		DNSDone:  func(httptrace.DNSDoneInfo) { hooks = append(hooks, "DNSDone") },
+		ConnectStart: func(network, addr string) {
+			hooks = append(hooks, "ConnectStart")
+		},
+		ConnectDone: func(network, addr string, err error) {
+			hooks = append(hooks, "ConnectDone")
+		},
+		TLSHandshakeStart: func() { hooks = append(hooks, "TLSHandshakeStart") },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { hooks = append(hooks, "TLSHandshakeDone") },
+		GotConn: func(info httptrace.GotConnInfo) {
+			hooks = append(hooks, "GotConn")
+			reused = info.Reused
+		},
+		WroteHeaders:         func() { hooks = append(hooks, "WroteHeaders") },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { hooks = append(hooks, "WroteRequest") },
+		GotFirstResponseByte: func() { hooks = append(hooks, "GotFirstResponseByte") },
+	}
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	for _, test := range []struct {
+		hooks  []string
+		reused bool
+	}{
+		{hooks: []string{"GetConn", "DNSStart", "DNSDone", "ConnectStart", "ConnectDone", "GotConn", "WroteHeaders", "WroteRequest", "GotFirstResponseByte"}},
+		{hooks: []string{"GetConn", "GotConn", "WroteHeaders", "WroteRequest", "GotFirstResponseByte"}, reused: true},
+	} {
+		hooks = nil
+		request, _ := http.NewRequest(http.MethodGet, u.String(), nil)
+		request = request.WithContext(httptrace.WithClientTrace(request.Context(), trace))
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ioutil.ReadAll(response.Body)
+		response.Body.Close()
+
+		if !reflect.DeepEqual(hooks, test.hooks) {
+			t.Errorf("hooks should be %v and are %v.", test.hooks, hooks)
+		}
+		if reused != test.reused {
+			t.Errorf("connection reused should be %v and is %v.", test.reused, reused)
+		}
+	}
+}
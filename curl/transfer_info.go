@@ -0,0 +1,22 @@
+package curl
+
+import (
+	"context"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+type transferInfoKey struct{}
+
+// WithTransferInfo returns a context whose requests sent over libcurl report
+// their libcurl.TransferInfo to sink once the transfer is over, failed
+// transfers included. sink is called on the goroutine driving the transfers,
+// it must not block.
+func WithTransferInfo(ctx context.Context, sink func(*libcurl.TransferInfo)) context.Context {
+	return context.WithValue(ctx, transferInfoKey{}, sink)
+}
+
+func transferInfoSink(ctx context.Context) func(*libcurl.TransferInfo) {
+	sink, _ := ctx.Value(transferInfoKey{}).(func(*libcurl.TransferInfo))
+	return sink
+}
@@ -0,0 +1,59 @@
+package curl
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+func TestWithTransferInfo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(strings.Repeat("a", 1000)))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	infos := make(chan *libcurl.TransferInfo, 1)
+	ctx := WithTransferInfo(context.Background(), func(info *libcurl.TransferInfo) {
+		infos <- info
+	})
+	request, _ := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/info", strings.NewReader("hello"))
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	var info *libcurl.TransferInfo
+	select {
+	case info = <-infos:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink should get the info of the transfer.")
+	}
+
+	if info.ResponseCode != http.StatusOK || info.HTTPVersion != libcurl.HTTP_VERSION_1_1 || info.EffectiveURL != ts.URL+"/info" {
+		t.Errorf("info should be of the 200 HTTP/1.1 response of %s and is %+v.", ts.URL+"/info", info)
+	}
+	if info.SizeUpload != 5 || info.SizeDownload != 1000 || info.HeaderSize <= 0 || info.RequestSize <= 0 {
+		t.Errorf("info should count 5 bytes up and 1000 down and is %+v.", info)
+	}
+	if port, _ := strconv.Atoi(u.Port()); info.PrimaryIP != "127.0.0.1" || info.PrimaryPort != port || info.LocalPort <= 0 || info.NumConnects != 1 {
+		t.Errorf("info should have the new connection to %s and is %+v.", u.Host, info)
+	}
+	if info.ConnectTime <= 0 || info.PreTransferTime < info.ConnectTime ||
+		info.StartTransferTime < info.PreTransferTime || info.TotalTime < info.StartTransferTime {
+		t.Errorf("timings should be set in the order of the phases and are %+v.", info)
+	}
+}
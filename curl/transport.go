@@ -1,31 +1,319 @@
 package curl
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
 )
 
 type Transport struct {
+	// Transport sends the requests which do not go through libcurl, its
+	// timeouts, proxy, TLS and connection settings also apply to libcurl.
+	// libcurl reads the RootCAs of TLSClientConfig from a file, so they
+	// must be created by NewCertPool. libcurl requests with another pool,
+	// like x509.SystemCertPool, fail. Leave RootCAs nil for the CA bundle
+	// of libcurl.
 	Transport *http.Transport
+	// Dialer is the Timeout, KeepAlive, LocalAddr and FallbackDelay of the
+	// connections libcurl opens, a zero net.Dialer if nil like the Dialer
+	// of a http.Transport without DialContext.
+	Dialer *net.Dialer
+
+	// Protocol selects between Transport and libcurl and the HTTP version of libcurl
+	Protocol Protocol
+	// Deprecated: use Protocol: ProtocolHTTP3
+	ForceHTTP3 bool
 
 	CAPath         string
-	ForceHTTP3     bool
 	HTTP3LogEnable bool
 	Timeout        int64 // 单位：ms
+
+	// Logger receives the verbose output of libcurl transfers like curl -v,
+	// text prefixed with "* ", the sent header with "> " and the received
+	// header with "< ". Setting it turns the verbose output on, without it
+	// HTTP3LogEnable writes to stderr.
+	Logger Logger
+
+	// how long an origin is sent with Transport after its HTTP/3 connection
+	// failed with ProtocolHTTP3Upgrade, 5 minutes if zero
+	HTTP3BrokenTimeout time.Duration
+
+	// the cookies of Jar are sent and the cookies the response sets are
+	// stored in it, on the libcurl path it is synced with the cookie engine
+	// of libcurl. Use it instead of http.Client.Jar, which would send the
+	// cookies twice.
+	Jar http.CookieJar
+	// CookieFile is a netscape cookie file libcurl reads before and writes
+	// after each libcurl transfer. Concurrent transfers each write their own
+	// cookies, the last one to finish wins.
+	CookieFile string
+
+	// UnixSockets maps the hosts of URLs, host:port or host for every port,
+	// to the unix sockets their requests are sent to. A path starting with @
+	// is in the abstract namespace. Requests to these hosts always go
+	// through libcurl, over HTTP/1.1 unless Protocol selects HTTP/2.
+	UnixSockets map[string]string
+
+	// Resolve are OPT_RESOLVE entries of libcurl requests, "host:port:addr[,addr]..."
+	// sets the addresses of a host and "-host:port" removes them
+	Resolve []string
+	// ConnectTo are OPT_CONNECT_TO rules of libcurl requests,
+	// "host:port:connect-to-host:connect-to-port", empty parts match all
+	ConnectTo []string
+	// DNS servers, "host[:port]", the network interface and the local
+	// addresses libcurl resolves with, they need libcurl built with c-ares
+	DNSServers   []string
+	DNSInterface string
+	DNSLocalIP4  string
+	DNSLocalIP6  string
+	// how long libcurl caches resolved hosts, 60 seconds if zero, a negative
+	// value disables the cache
+	DNSCacheTimeout time.Duration
+	// DNSShuffleAddresses shuffles the addresses of a host before they are tried
+	DNSShuffleAddresses bool
+	// IPResolve limits the addresses libcurl uses, one of the
+	// libcurl.IPRESOLVE_* constants
+	IPResolve int
+	// Resolver resolves the host of libcurl requests before the transfer
+	// starts, like net.DefaultResolver.LookupHost. The addresses are passed to
+	// libcurl with OPT_RESOLVE, Resolve entries of the same host win.
+	// It is not called for IP literals, proxied requests and unix sockets.
+	Resolver func(ctx context.Context, host string) ([]string, error)
+	// DoHURL is the DNS-over-HTTPS server libcurl resolves hosts with, like
+	// https://dns.example/dns-query. Its TLS is verified with the TLS config
+	// of the request. A host it can not resolve fails with a *DoHError.
+	DoHURL string
+
+	loopMu sync.Mutex
+	loop   *multiLoop
+
+	altSvc altSvcCache
 }
 
 func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	protocol := t.Protocol
+	if protocol == ProtocolTransport && t.ForceHTTP3 {
+		protocol = ProtocolHTTP3
+	}
+
+	if socketPath := unixSocketPath(t.UnixSockets, request.URL); socketPath != "" {
+		httpVersion, ok := protocolHTTPVersions[protocol]
+		if !ok || httpVersion == libcurl.HTTP_VERSION_3 {
+			httpVersion = libcurl.HTTP_VERSION_1_1
+		}
+		return t.roundTripCurl(request, httpVersion, "", socketPath)
+	}
+
+	switch protocol {
+	case ProtocolTransport:
+		return t.roundTripTransport(request)
+	case ProtocolHTTP3Upgrade:
+		return t.roundTripAltSvc(request)
+	default:
+		httpVersion, ok := protocolHTTPVersions[protocol]
+		if !ok {
+			return nil, fmt.Errorf("curl: unknown protocol %d", protocol)
+		}
+		return t.roundTripCurl(request, httpVersion, "", "")
+	}
+}
+
+// roundTripCurl sends request with libcurl, to unixSocket when it is not empty
+func (t *Transport) roundTripCurl(request *http.Request, httpVersion int, altSvcPort, unixSocket string) (*http.Response, error) {
+	loop, err := t.multiLoop()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http3Transport{
+		ResolverList:  t.Resolve,
+		ConnectToList: t.ConnectTo,
+		DNS: dnsConfig{
+			Servers:          t.DNSServers,
+			Interface:        t.DNSInterface,
+			LocalIP4:         t.DNSLocalIP4,
+			LocalIP6:         t.DNSLocalIP6,
+			CacheTimeout:     t.DNSCacheTimeout,
+			ShuffleAddresses: t.DNSShuffleAddresses,
+			IPResolve:        t.IPResolve,
+			Resolver:         t.Resolver,
+			DoHURL:           t.DoHURL,
+		},
+		CAPath:          t.CAPath,
+		TLSClientConfig: t.Transport.TLSClientConfig,
+		HTTP3LogEnable:  t.HTTP3LogEnable,
+		Logger:          t.Logger,
+		HTTPVersion:     httpVersion,
+		ConnectTimeout:  t.connectTimeout(request),
+		Timeout:         t.Timeout,
+		AltSvcPort:      altSvcPort,
+		UnixSocket:      unixSocket,
+		Jar:             t.Jar,
+		CookieFile:      t.CookieFile,
+		Conn:            newConnConfig(t.dialer(), t.Transport),
+
+		ResponseHeaderTimeout:  t.Transport.ResponseHeaderTimeout,
+		ExpectContinueTimeout:  t.Transport.ExpectContinueTimeout,
+		DisableCompression:     t.Transport.DisableCompression,
+		MaxResponseHeaderBytes: t.Transport.MaxResponseHeaderBytes,
+
+		Proxy:              t.Transport.Proxy,
+		ProxyConnectHeader: t.Transport.ProxyConnectHeader,
+
+		loop: loop,
+	}
+	return transport.RoundTrip(request)
+}
+
+func (t *Transport) multiLoop() (*multiLoop, error) {
+	t.loopMu.Lock()
+	defer t.loopMu.Unlock()
+
+	if t.loop == nil {
+		loop, err := newMultiLoop(t.Transport.MaxConnsPerHost, t.Transport.MaxIdleConns)
+		if err != nil {
+			return nil, err
+		}
+		t.loop = loop
+	}
+	return t.loop, nil
+}
+
+// CloseIdleConnections closes the idle connections of Transport and of
+// libcurl, http.Client.CloseIdleConnections calls it. The connections of
+// libcurl are closed by cleaning up its multi handle, which waits until
+// the running libcurl transfers are over.
+func (t *Transport) CloseIdleConnections() {
+	t.Transport.CloseIdleConnections()
+
+	t.loopMu.Lock()
+	loop := t.loop
+	t.loopMu.Unlock()
+	if loop != nil {
+		loop.closeIdle()
+	}
+}
 
-	if t.ForceHTTP3 {
-		transport := &http3Transport{
-			ResolverList:   nil,
-			CAPath:         t.CAPath,
-			HTTP3LogEnable: t.HTTP3LogEnable,
-			ConnectTimeout: int64(t.Transport.IdleConnTimeout / time.Millisecond),
-			Timeout:        t.Timeout,
+func (t *Transport) dialer() *net.Dialer {
+	if t.Dialer == nil {
+		return &net.Dialer{}
+	}
+	return t.Dialer
+}
+
+// connectTimeout is the connect timeout of libcurl in ms, which includes the
+// TLS handshake, 0 for none.
+func (t *Transport) connectTimeout(request *http.Request) int64 {
+	timeout := t.dialer().Timeout
+	if request.URL.Scheme == "https" {
+		timeout += t.Transport.TLSHandshakeTimeout
+	}
+	return int64(timeout / time.Millisecond)
+}
+
+// roundTripAltSvc uses HTTP/3 for the origins which advertised it and falls
+// back to Transport when the HTTP/3 connection fails. Other HTTP/3 errors
+// are only retried with Transport for replayable requests.
+func (t *Transport) roundTripAltSvc(request *http.Request) (*http.Response, error) {
+	// an upgraded connection can not be HTTP/3
+	origin := altSvcOrigin(request.URL)
+	if origin == "" || isUpgradeRequest(request) {
+		return t.roundTripTransport(request)
+	}
+
+	if port, ok := t.altSvc.lookup(origin, time.Now()); ok {
+		response, err := t.roundTripCurl(request, libcurl.HTTP_VERSION_3, port, "")
+		if err == nil {
+			t.altSvc.update(origin, request.URL.Hostname(), response.Header.Values("Alt-Svc"), time.Now())
+			return response, nil
 		}
-		return transport.RoundTrip(request)
-	} else {
+
+		var connectErr *altSvcConnectError
+		if errors.As(err, &connectErr) {
+			err = connectErr.err
+		}
+		if request.Context().Err() != nil {
+			return nil, err
+		}
+
+		if connectErr != nil {
+			// the request was not sent, HTTP/3 does not work for the origin
+			brokenTimeout := t.HTTP3BrokenTimeout
+			if brokenTimeout <= 0 {
+				brokenTimeout = defaultHTTP3BrokenTimeout
+			}
+			t.altSvc.markBroken(origin, time.Now(), brokenTimeout)
+		} else if !isReplayable(request) {
+			// the server may have handled the request
+			return nil, err
+		}
+
+		// the HTTP/3 attempt may have consumed the body
+		if request, ok = rewindRequest(request); !ok {
+			return nil, err
+		}
+	}
+
+	response, err := t.roundTripTransport(request)
+	if err == nil {
+		t.altSvc.update(origin, request.URL.Hostname(), response.Header.Values("Alt-Svc"), time.Now())
+	}
+	return response, err
+}
+
+// isReplayable reports whether request can be sent again after it may have
+// reached the server, for idempotent methods or when the caller can provide
+// the body again with GetBody.
+func isReplayable(request *http.Request) bool {
+	switch request.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return request.GetBody != nil
+}
+
+// rewindRequest returns request with a fresh body so it can be sent again,
+// ok is false when the body can not be read again.
+func rewindRequest(request *http.Request) (*http.Request, bool) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return request, true
+	}
+	if request.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	newRequest := request.Clone(request.Context())
+	newRequest.Body = body
+	return newRequest, true
+}
+
+// roundTripTransport sends request with Transport
+func (t *Transport) roundTripTransport(request *http.Request) (*http.Response, error) {
+	if t.Jar == nil {
 		return t.Transport.RoundTrip(request)
 	}
+
+	// a RoundTripper must not modify the request
+	request = request.Clone(request.Context())
+	for _, cookie := range t.Jar.Cookies(request.URL) {
+		request.AddCookie(cookie)
+	}
+
+	response, err := t.Transport.RoundTrip(request)
+	if err == nil {
+		if cookies := response.Cookies(); len(cookies) > 0 {
+			t.Jar.SetCookies(request.URL, cookies)
+		}
+	}
+	return response, err
 }
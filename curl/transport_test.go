@@ -0,0 +1,358 @@
+package curl
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTransportProtocol(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	defer ts.Close()
+
+	for _, protocol := range []Protocol{ProtocolTransport, ProtocolHTTP1, ProtocolHTTP2, ProtocolHTTP2TLS} {
+		client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: protocol}}
+		response, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("protocol %d: %v", protocol, err)
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			t.Fatalf("protocol %d: %v", protocol, err)
+		}
+		if string(body) != "HTTP/1.1" || response.Proto != "HTTP/1.1" {
+			t.Errorf("protocol %d should fall back to HTTP/1.1 and is %s, %s.", protocol, response.Proto, body)
+		}
+	}
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP3}}
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Error("HTTP/3 only should fail for a http URL.")
+	}
+}
+
+func TestTransportTimeoutsAndLimits(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		case "/large":
+			w.Header().Set("X-Large", strings.Repeat("a", 2000))
+		}
+		w.Write([]byte(r.RemoteAddr))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{ResponseHeaderTimeout: 100 * time.Millisecond, MaxResponseHeaderBytes: 1000},
+		Protocol:  ProtocolHTTP1,
+	}}
+
+	if _, err := client.Get(ts.URL + "/slow"); !errors.Is(err, errResponseHeaderTimeout) {
+		t.Errorf("slow response should time out and is %v.", err)
+	}
+	if _, err := client.Get(ts.URL + "/large"); err == nil || !strings.Contains(err.Error(), "exceeded 1000 bytes") {
+		t.Errorf("large header should exceed the limit and is %v.", err)
+	}
+	response, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+}
+
+func TestTransportMaxResponseHeaderBytes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Large", strings.Repeat("a", 2000))
+		w.Write([]byte("body"))
+	}))
+	defer ts.Close()
+
+	// without ResponseHeaderTimeout nothing else ends the transfer
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{MaxResponseHeaderBytes: 1000},
+		Protocol:  ProtocolHTTP1,
+	}}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Get(ts.URL)
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		if err == nil || !strings.Contains(err.Error(), "exceeded 1000 bytes") {
+			t.Errorf("large header should exceed the limit and is %v.", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("large header should abort the transfer.")
+	}
+}
+
+func TestTransportContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/body" {
+			w.Write([]byte("header"))
+			w.(http.Flusher).Flush()
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	for _, test := range []struct {
+		path     string
+		deadline bool
+		want     error
+	}{
+		{path: "/header", want: context.Canceled},
+		{path: "/header", deadline: true, want: context.DeadlineExceeded},
+		{path: "/body", want: context.Canceled},
+		{path: "/body", deadline: true, want: context.DeadlineExceeded},
+	} {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if test.deadline {
+			ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
+			time.AfterFunc(200*time.Millisecond, cancel)
+		}
+
+		request, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+test.path, nil)
+		response, err := client.Do(request)
+		if err == nil {
+			// the header arrived, the body fails once the context is done
+			_, err = ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			if test.path != "/body" {
+				t.Errorf("%s: response should fail before the header.", test.path)
+			}
+		} else if test.path == "/body" {
+			t.Errorf("%s: header should arrive before the context is done, %v.", test.path, err)
+		}
+		cancel()
+
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s deadline %v: error should be %v and is %v.", test.path, test.deadline, test.want, err)
+		}
+	}
+}
+
+func TestTransportConcurrentRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			response, err := client.Get(ts.URL + path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			body, err := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			if err == nil && string(body) != path {
+				err = fmt.Errorf("body of %s is %q", path, body)
+			}
+			if err != nil {
+				errs <- err
+			}
+		}(fmt.Sprintf("/%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestTransportCloseIdleConnections(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+	get := func() string {
+		response, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		return string(addr)
+	}
+
+	first := get()
+	if second := get(); second != first {
+		t.Fatalf("idle connection should be reused, %s and %s.", first, second)
+	}
+	client.CloseIdleConnections()
+	if third := get(); third == first {
+		t.Errorf("idle connection %s should be closed.", first)
+	}
+}
+
+func TestTransportDisableKeepAlives(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	}))
+	defer ts.Close()
+
+	for _, disableKeepAlives := range []bool{false, true} {
+		client := &http.Client{Transport: &Transport{
+			Transport: &http.Transport{DisableKeepAlives: disableKeepAlives},
+			Protocol:  ProtocolHTTP1,
+		}}
+
+		var addrs []string
+		for i := 0; i < 2; i++ {
+			response, err := client.Get(ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			addr, _ := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			addrs = append(addrs, string(addr))
+		}
+		if reused := addrs[0] == addrs[1]; reused == disableKeepAlives {
+			t.Errorf("DisableKeepAlives %v: connection reused is %v.", disableKeepAlives, reused)
+		}
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	transport := &Transport{
+		Transport: &http.Transport{TLSHandshakeTimeout: 2 * time.Second, IdleConnTimeout: time.Minute},
+		Dialer:    &net.Dialer{Timeout: 3 * time.Second},
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if timeout := transport.connectTimeout(request); timeout != 5000 {
+		t.Errorf("https connect timeout should be 5000 and is %d.", timeout)
+	}
+	request, _ = http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if timeout := transport.connectTimeout(request); timeout != 3000 {
+		t.Errorf("http connect timeout should be 3000 and is %d.", timeout)
+	}
+}
+
+func TestTransportDecompression(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte("plain"))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte("compressed"))
+		zw.Close()
+	}))
+	defer ts.Close()
+
+	for _, test := range []struct {
+		disableCompression bool
+		acceptEncoding     string
+		body               string
+		uncompressed       bool
+	}{
+		{body: "compressed", uncompressed: true},
+		{disableCompression: true, body: "plain"},
+		{acceptEncoding: "identity", body: "plain"},
+	} {
+		client := &http.Client{Transport: &Transport{
+			Transport: &http.Transport{DisableCompression: test.disableCompression},
+			Protocol:  ProtocolHTTP1,
+		}}
+
+		request, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+		if test.acceptEncoding != "" {
+			request.Header.Set("Accept-Encoding", test.acceptEncoding)
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != test.body || response.Uncompressed != test.uncompressed {
+			t.Errorf("%+v: body is %q and uncompressed is %v.", test, body, response.Uncompressed)
+		}
+		if test.uncompressed && (response.Header.Get("Content-Encoding") != "" || response.ContentLength != -1) {
+			t.Errorf("decoded response should drop the encoding and length, %v %d.", response.Header, response.ContentLength)
+		}
+	}
+}
+
+type testLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+	l.mu.Unlock()
+}
+
+func TestTransportLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("body"))
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1, Logger: logger}}
+	response, err := client.Get(ts.URL + "/logged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	for _, prefix := range []string{"* ", "> GET /logged HTTP/1.1", "< HTTP/1.1 200 OK"} {
+		found := false
+		for _, line := range logger.lines {
+			found = found || strings.HasPrefix(line, prefix)
+		}
+		if !found {
+			t.Errorf("log should have a line starting with %q and is %q.", prefix, logger.lines)
+		}
+	}
+	for _, line := range logger.lines {
+		if strings.Contains(line, "body") {
+			t.Errorf("log should not have the data and has %q.", line)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package curl
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// unixSocketPath returns the socket of the host of u in sockets, keyed by
+// host:port or by host for every port. Empty when the host is not mapped.
+func unixSocketPath(sockets map[string]string, u *url.URL) string {
+	if len(sockets) == 0 {
+		return ""
+	}
+	if path, ok := sockets[u.Host]; ok {
+		return path
+	}
+	return sockets[u.Hostname()]
+}
+
+// setupUnixSocket connects the transfer to the unix socket path instead of
+// the host of the URL, a path starting with @ is in the abstract namespace
+// like with package net.
+func setupUnixSocket(easy *libcurl.CURL, path string) (err error) {
+	if path == "" {
+		return
+	}
+
+	if strings.HasPrefix(path, "@") {
+		err = easy.Setopt(libcurl.OPT_ABSTRACT_UNIX_SOCKET, path[1:])
+	} else {
+		err = easy.Setopt(libcurl.OPT_UNIX_SOCKET_PATH, path)
+	}
+	return
+}
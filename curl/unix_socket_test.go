@@ -0,0 +1,74 @@
+package curl
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func newUnixServer(t *testing.T, path string) *httptest.Server {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path))
+	}))
+	ts.Listener = listener
+	ts.Start()
+	return ts
+}
+
+func TestTransportUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-curl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "http.sock")
+	ts := newUnixServer(t, socketPath)
+	defer ts.Close()
+
+	sockets := map[string]string{"docker": socketPath}
+	urls := []string{"http://docker/version"}
+	// the abstract namespace is linux only
+	if runtime.GOOS == "linux" {
+		abstractPath := "@go-curl-" + strconv.Itoa(os.Getpid())
+		abstractServer := newUnixServer(t, abstractPath)
+		defer abstractServer.Close()
+		sockets["agent:8080"] = abstractPath
+		urls = append(urls, "http://agent:8080/status")
+	}
+
+	for _, protocol := range []Protocol{ProtocolTransport, ProtocolHTTP1, ProtocolHTTP3} {
+		client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: protocol, UnixSockets: sockets}}
+		for _, u := range urls {
+			response, err := client.Get(u)
+			if err != nil {
+				t.Fatalf("protocol %d %s: %v", protocol, u, err)
+			}
+			body, err := ioutil.ReadAll(response.Body)
+			response.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := u[len("http://"):]; string(body) != want {
+				t.Errorf("protocol %d: response should be %s and is %s.", protocol, want, body)
+			}
+		}
+	}
+
+	// only the mapped port goes to the socket
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1, UnixSockets: map[string]string{"docker:80": socketPath}}}
+	if _, err := client.Get("http://docker:8080/version"); err == nil {
+		t.Error("an unmapped port should not use the socket.")
+	}
+}
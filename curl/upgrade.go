@@ -0,0 +1,330 @@
+package curl
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YangSen-qn/go-curl/v2/libcurl"
+)
+
+// how long a blocked read or write waits before it checks for Close
+const upgradeWaitInterval = 100 * time.Millisecond
+
+var errUpgradeConnClosed = errors.New("curl: upgraded connection closed")
+
+// isUpgradeRequest reports whether request takes over its connection, like a
+// CONNECT or a request asking for a protocol upgrade.
+func isUpgradeRequest(request *http.Request) bool {
+	if request.Method == http.MethodConnect {
+		return true
+	}
+	if request.Header.Get("Upgrade") == "" {
+		return false
+	}
+	for _, value := range request.Header.Values("Connection") {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roundTripUpgrade sends request on a connection libcurl only opens, with
+// OPT_CONNECT_ONLY, and reads the response itself. Like http.Transport the
+// body of a 101 response, or of a 2xx response to CONNECT, is an
+// io.ReadWriteCloser on the connection.
+func (t *http3Transport) roundTripUpgrade(request *http.Request) (response *http.Response, err error) {
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	if err = globalInit(); err != nil {
+		return
+	}
+
+	easyLock.Lock()
+	easy := libcurl.EasyInit()
+	easyLock.Unlock()
+
+	if easy == nil {
+		err = errors.New("create easy handle error")
+		return
+	}
+
+	connected := false
+	defer func() {
+		if !connected {
+			cleanupEasy(easy)
+		}
+	}()
+
+	ctx := request.Context()
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	// the request is written on the connection, a HTTP proxy has to tunnel
+	// it, and an upgrade takes over a HTTP/1.1 connection
+	requestURL, _, resolveHost, err := t.setupEasy(easy, request, libcurl.HTTP_VERSION_1_1, true)
+	if err != nil {
+		return
+	}
+
+	err = easy.Setopt(libcurl.OPT_CONNECT_ONLY, 1)
+	if err != nil {
+		return
+	}
+
+	// the connect runs on the calling goroutine, the xferinfo callback
+	// aborts it with the context
+	err = easy.Setopt(libcurl.OPT_NOPROGRESS, 0)
+	if err != nil {
+		return
+	}
+
+	err = easy.Setopt(libcurl.OPT_XFERINFOFUNCTION, func(dltotal, dlnow, ultotal, ulnow int64, userData interface{}) error {
+		return ctx.Err()
+	})
+	if err != nil {
+		return
+	}
+
+	err = easy.Perform()
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		err = ctxErr
+	} else if resolveHost != "" {
+		err = dohError(&t.DNS, resolveHost, err)
+	}
+	if err != nil {
+		return
+	}
+
+	socketI, err := easy.Getinfo(libcurl.INFO_ACTIVESOCKET)
+	if err != nil {
+		return
+	}
+	socket, _ := socketI.(int)
+
+	connected = true
+	conn := &upgradeConn{easy: easy, socket: socket}
+	defer func() {
+		if err != nil {
+			conn.Close()
+		}
+	}()
+
+	// abort a blocked write or read of the response with the context
+	responseRead := make(chan struct{})
+	defer close(responseRead)
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+			case <-responseRead:
+			}
+		}()
+	}
+
+	outgoing := request
+	if t.Jar != nil {
+		outgoing = request.Clone(ctx)
+		for _, cookie := range t.Jar.Cookies(request.URL) {
+			outgoing.AddCookie(cookie)
+		}
+	}
+
+	err = outgoing.Write(conn)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return
+	}
+
+	// like http.Transport, the wait for the header is limited by
+	// ResponseHeaderTimeout and its size by MaxResponseHeaderBytes
+	headerTimedOut := make(chan struct{})
+	var headerTimer *time.Timer
+	if t.ResponseHeaderTimeout > 0 {
+		headerTimer = time.AfterFunc(t.ResponseHeaderTimeout, func() {
+			close(headerTimedOut)
+			conn.Close()
+		})
+	}
+
+	maxHeaderBytes := t.maxResponseHeaderBytes()
+	limited := &io.LimitedReader{R: conn, N: maxHeaderBytes}
+	reader := bufio.NewReader(limited)
+	for {
+		response, err = http.ReadResponse(reader, request)
+		if err != nil {
+			select {
+			case <-headerTimedOut:
+				err = errResponseHeaderTimeout
+			default:
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				} else if limited.N <= 0 {
+					err = responseHeaderLimitError(maxHeaderBytes)
+				}
+			}
+			return
+		}
+		// skip informational responses other than the upgrade
+		if response.StatusCode >= http.StatusOK || response.StatusCode == http.StatusSwitchingProtocols {
+			break
+		}
+	}
+	if headerTimer != nil && !headerTimer.Stop() {
+		response, err = nil, errResponseHeaderTimeout
+		return
+	}
+	limited.N = math.MaxInt64
+
+	if t.Jar != nil {
+		if cookies := response.Cookies(); len(cookies) > 0 {
+			t.Jar.SetCookies(request.URL, cookies)
+		}
+	}
+
+	if requestURL.Scheme == "https" {
+		response.TLS = connectionState(easy, requestURL.Hostname())
+	}
+
+	upgraded := response.StatusCode == http.StatusSwitchingProtocols ||
+		(request.Method == http.MethodConnect && response.StatusCode/100 == 2)
+	if upgraded {
+		response.Body = &upgradeBody{upgradeResponseBody{reader: reader, conn: conn}}
+	} else {
+		response.Body = &upgradeResponseBody{reader: response.Body, conn: conn}
+	}
+	return
+}
+
+// upgradeConn reads and writes the connection of a OPT_CONNECT_ONLY
+// transfer, it waits for the socket while libcurl returns E_AGAIN.
+type upgradeConn struct {
+	easy   *libcurl.CURL
+	socket int
+
+	// serializes Send, Recv and the cleanup of easy
+	mu     sync.Mutex
+	closed bool
+}
+
+func (c *upgradeConn) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			return 0, errUpgradeConnClosed
+		}
+		n, err := c.easy.Recv(p)
+		c.mu.Unlock()
+
+		if err == libcurl.CurlError(libcurl.E_AGAIN) {
+			if err = c.wait(false); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		// the peer closed the connection
+		if n == 0 {
+			return 0, io.EOF
+		}
+		return n, nil
+	}
+}
+
+func (c *upgradeConn) Write(p []byte) (written int, err error) {
+	for written < len(p) {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			return written, errUpgradeConnClosed
+		}
+		n, sendErr := c.easy.Send(p[written:])
+		c.mu.Unlock()
+
+		written += n
+		if sendErr == libcurl.CurlError(libcurl.E_AGAIN) {
+			if err = c.wait(true); err != nil {
+				return
+			}
+			continue
+		}
+		if sendErr != nil {
+			return written, sendErr
+		}
+	}
+	return
+}
+
+// wait blocks until the socket is ready or the connection is closed
+func (c *upgradeConn) wait(forSend bool) error {
+	for {
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return errUpgradeConnClosed
+		}
+
+		ready, err := libcurl.WaitSocket(c.socket, forSend, int(upgradeWaitInterval/time.Millisecond))
+		if err != nil || ready {
+			return err
+		}
+	}
+}
+
+func (c *upgradeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	cleanupEasy(c.easy)
+	return nil
+}
+
+// upgradeResponseBody is the body of a response read by roundTripUpgrade,
+// it closes the connection with the body.
+type upgradeResponseBody struct {
+	reader io.Reader
+	conn   *upgradeConn
+}
+
+func (b *upgradeResponseBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+func (b *upgradeResponseBody) Close() error {
+	return b.conn.Close()
+}
+
+// upgradeBody is the body of an upgraded connection, it also writes to it
+type upgradeBody struct {
+	upgradeResponseBody
+}
+
+func (b *upgradeBody) Write(p []byte) (int, error) {
+	return b.conn.Write(p)
+}
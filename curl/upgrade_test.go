@@ -0,0 +1,117 @@
+package curl
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransportUpgrade(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "echo" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		rw.Flush()
+		io.Copy(conn, rw)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{Transport: &http.Transport{}, Protocol: ProtocolHTTP1}}
+
+	request, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "echo")
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("upgrade should switch protocols and is %d.", response.StatusCode)
+	}
+	conn, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		t.Fatal("the body of an upgrade should be writable.")
+	}
+
+	reader := bufio.NewReader(conn)
+	for _, message := range []string{"hello\n", "world\n"} {
+		if _, err = conn.Write([]byte(message)); err != nil {
+			t.Fatal(err)
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != message {
+			t.Errorf("echo should be %q and is %q.", message, line)
+		}
+	}
+
+	// a rejected upgrade is a normal response
+	request, _ = http.NewRequest(http.MethodGet, ts.URL, nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "other")
+	response, err = client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("rejected upgrade should be %d and is %d.", http.StatusBadRequest, response.StatusCode)
+	}
+	if _, ok := response.Body.(io.Writer); ok {
+		t.Error("the body of a rejected upgrade should not be writable.")
+	}
+}
+
+func TestTransportUpgradeHeaderLimits(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		case "/large":
+			w.Header().Set("X-Large", strings.Repeat("a", 2000))
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &Transport{
+		Transport: &http.Transport{ResponseHeaderTimeout: 100 * time.Millisecond, MaxResponseHeaderBytes: 1000},
+		Protocol:  ProtocolHTTP1,
+	}}
+	upgrade := func(path string) (*http.Response, error) {
+		request, _ := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		request.Header.Set("Connection", "Upgrade")
+		request.Header.Set("Upgrade", "echo")
+		return client.Do(request)
+	}
+
+	if _, err := upgrade("/slow"); !errors.Is(err, errResponseHeaderTimeout) {
+		t.Errorf("slow response should time out and is %v.", err)
+	}
+	if _, err := upgrade("/large"); err == nil || !strings.Contains(err.Error(), "exceeded 1000 bytes") {
+		t.Errorf("large header should exceed the limit and is %v.", err)
+	}
+	response, err := upgrade("/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+}
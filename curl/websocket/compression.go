@@ -0,0 +1,169 @@
+package websocket
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// the client compresses each message on its own, so the server does not need
+// to keep a window for it
+const compressionOffer = "permessage-deflate; client_no_context_takeover"
+
+const (
+	// the largest window of RFC 7692, a smaller one negotiated with
+	// server_max_window_bits is decompressed as well
+	maxWindowSize = 1 << 15
+
+	// RFC 7692 removes the end of the empty stored block a flush writes from
+	// every message
+	deflateTailSize = 4
+)
+
+var deflateTail = []byte{0x00, 0x00, 0xff, 0xff}
+
+// an empty final stored block, it ends the stream of a message so the
+// reader returns io.EOF
+var deflateFinalBlock = []byte{0x01, 0x00, 0x00, 0xff, 0xff}
+
+// compressionConfig is the negotiated permessage-deflate, nil when disabled
+type compressionConfig struct {
+	// the peer compresses each message on its own, the read window is reset
+	// for every message
+	readNoContextTakeover bool
+}
+
+// negotiateCompression parses the Sec-WebSocket-Extensions the server
+// accepted
+func negotiateCompression(header http.Header, offered bool) (*compressionConfig, error) {
+	var config *compressionConfig
+	for _, value := range header.Values("Sec-WebSocket-Extensions") {
+		for _, extension := range strings.Split(value, ",") {
+			params := strings.Split(extension, ";")
+			name := strings.TrimSpace(params[0])
+			if name == "" {
+				continue
+			}
+			if name != "permessage-deflate" || !offered || config != nil {
+				return nil, fmt.Errorf("websocket: unexpected extension %q", strings.TrimSpace(extension))
+			}
+
+			config = &compressionConfig{}
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				key := param
+				if i := strings.IndexByte(param, '='); i >= 0 {
+					key = strings.TrimSpace(param[:i])
+				}
+				switch key {
+				case "server_no_context_takeover":
+					config.readNoContextTakeover = true
+				case "client_no_context_takeover", "server_max_window_bits":
+				default:
+					return nil, fmt.Errorf("websocket: unexpected permessage-deflate parameter %q", param)
+				}
+			}
+		}
+	}
+	return config, nil
+}
+
+// decompressor inflates the messages of the peer, with context takeover the
+// window of the previous messages is kept as dictionary.
+type decompressor struct {
+	reader io.ReadCloser
+	window []byte
+}
+
+var errReadLimit = errors.New("websocket: read limit exceeded")
+
+// decompress inflates a message, limit is the largest result, 0 for no limit
+func (d *decompressor) decompress(payload []byte, limit int64, noContextTakeover bool) ([]byte, error) {
+	input := io.MultiReader(bytes.NewReader(payload), bytes.NewReader(deflateTail), bytes.NewReader(deflateFinalBlock))
+	if d.reader == nil {
+		d.reader = flate.NewReaderDict(input, d.window)
+	} else if err := d.reader.(flate.Resetter).Reset(input, d.window); err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader = d.reader
+	if limit > 0 {
+		reader = io.LimitReader(d.reader, limit+1)
+	}
+	var out bytes.Buffer
+	if _, err := out.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(out.Len()) > limit {
+		return nil, errReadLimit
+	}
+
+	if !noContextTakeover {
+		window := append(d.window, out.Bytes()...)
+		if len(window) > maxWindowSize {
+			window = window[len(window)-maxWindowSize:]
+		}
+		d.window = append([]byte(nil), window...)
+	}
+	return out.Bytes(), nil
+}
+
+// compressor deflates a message written in parts without context takeover.
+// The output of each part is flushed so it can be sent right away, the last
+// bytes are held back as they may be the tail the end of the message drops.
+type compressor struct {
+	writer  *flate.Writer
+	buf     bytes.Buffer
+	pending []byte
+}
+
+func newCompressor() *compressor {
+	c := &compressor{}
+	c.writer, _ = flate.NewWriter(&c.buf, flate.DefaultCompression)
+	return c
+}
+
+// reset starts a new message
+func (c *compressor) reset() {
+	c.buf.Reset()
+	c.pending = c.pending[:0]
+	c.writer.Reset(&c.buf)
+}
+
+// write compresses p and returns the output which can be sent
+func (c *compressor) write(p []byte) ([]byte, error) {
+	if _, err := c.writer.Write(p); err != nil {
+		return nil, err
+	}
+	if err := c.writer.Flush(); err != nil {
+		return nil, err
+	}
+	return c.take(), nil
+}
+
+// finish returns the rest of the message without the tail
+func (c *compressor) finish() ([]byte, error) {
+	if err := c.writer.Flush(); err != nil {
+		return nil, err
+	}
+	out := c.take()
+	c.pending = c.pending[:0]
+	return out, nil
+}
+
+// take moves the buffer to pending and returns all but its last bytes
+func (c *compressor) take() []byte {
+	c.pending = append(c.pending, c.buf.Bytes()...)
+	c.buf.Reset()
+	if len(c.pending) <= deflateTailSize {
+		return nil
+	}
+	n := len(c.pending) - deflateTailSize
+	out := append([]byte(nil), c.pending[:n]...)
+	c.pending = append(c.pending[:0], c.pending[n:]...)
+	return out
+}
@@ -0,0 +1,585 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// message types, the opcodes of RFC 6455
+const (
+	continuationFrame = 0
+	TextMessage       = 1
+	BinaryMessage     = 2
+	CloseMessage      = 8
+	PingMessage       = 9
+	PongMessage       = 10
+)
+
+// close codes of RFC 6455
+const (
+	CloseNormalClosure           = 1000
+	CloseGoingAway               = 1001
+	CloseProtocolError           = 1002
+	CloseUnsupportedData         = 1003
+	CloseNoStatusReceived        = 1005
+	CloseAbnormalClosure         = 1006
+	CloseInvalidFramePayloadData = 1007
+	ClosePolicyViolation         = 1008
+	CloseMessageTooBig           = 1009
+	CloseMandatoryExtension      = 1010
+	CloseInternalServerErr       = 1011
+)
+
+const (
+	finalBit = 0x80
+	rsv1Bit  = 0x40
+	rsvBits  = 0x70
+	maskBit  = 0x80
+
+	maxControlPayloadSize = 125
+
+	// a larger payload is not allocated up front, so a frame announcing
+	// more than the peer sends can not exhaust the memory
+	readChunkSize = 64 << 10
+)
+
+// DefaultReadLimit is the largest message ReadMessage accepts unless
+// SetReadLimit sets another limit
+const DefaultReadLimit = 32 << 20
+
+var ErrCloseSent = errors.New("websocket: close sent")
+
+// protocolError is a violation of RFC 6455 by the peer
+type protocolError string
+
+func (e protocolError) Error() string {
+	return "websocket: " + string(e)
+}
+
+// CloseError is returned by ReadMessage once the peer closed the connection
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	return "websocket: close " + strconv.Itoa(e.Code) + " " + e.Text
+}
+
+// FormatCloseMessage formats the payload of a close frame, CloseNoStatusReceived
+// has an empty payload.
+func FormatCloseMessage(code int, text string) []byte {
+	if code == CloseNoStatusReceived {
+		return []byte{}
+	}
+	p := make([]byte, 2+len(text))
+	binary.BigEndian.PutUint16(p, uint16(code))
+	copy(p[2:], text)
+	return p
+}
+
+// Conn is a WebSocket connection. One goroutine may read while others
+// write, each frame is written at once so control frames can be sent while a
+// message is written with NextWriter.
+type Conn struct {
+	rwc         io.ReadWriteCloser
+	reader      *bufio.Reader
+	server      bool
+	subprotocol string
+	compression *compressionConfig
+
+	writeMu    sync.Mutex
+	writeErr   error
+	closeSent  bool
+	compressor *compressor
+
+	readLimit    int64
+	readErr      error
+	decompressor decompressor
+	pingHandler  func(data []byte) error
+	pongHandler  func(data []byte) error
+}
+
+// newConn wraps a connection upgraded to WebSocket, server selects the role
+// for the masking of frames.
+func newConn(rwc io.ReadWriteCloser, server bool, compression *compressionConfig) *Conn {
+	c := &Conn{
+		rwc:         rwc,
+		reader:      bufio.NewReader(rwc),
+		server:      server,
+		compression: compression,
+	}
+	c.pingHandler = func(data []byte) error {
+		err := c.WriteControl(PongMessage, data)
+		if err == ErrCloseSent {
+			return nil
+		}
+		return err
+	}
+	c.pongHandler = func(data []byte) error { return nil }
+	return c
+}
+
+// Subprotocol is the subprotocol the server selected
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// SetReadLimit sets the largest message ReadMessage accepts, 0 for
+// DefaultReadLimit and a negative limit for none. A larger message closes
+// the connection with CloseMessageTooBig.
+func (c *Conn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+// messageLimit is the largest message ReadMessage accepts, 0 for no limit
+func (c *Conn) messageLimit() int64 {
+	switch {
+	case c.readLimit == 0:
+		return DefaultReadLimit
+	case c.readLimit < 0:
+		return 0
+	}
+	return c.readLimit
+}
+
+// SetPingHandler sets the handler of ping frames, the default one answers
+// with a pong.
+func (c *Conn) SetPingHandler(h func(data []byte) error) {
+	if h == nil {
+		h = func(data []byte) error { return nil }
+	}
+	c.pingHandler = h
+}
+
+// SetPongHandler sets the handler of pong frames
+func (c *Conn) SetPongHandler(h func(data []byte) error) {
+	if h == nil {
+		h = func(data []byte) error { return nil }
+	}
+	c.pongHandler = h
+}
+
+// Close closes the connection without a close handshake
+func (c *Conn) Close() error {
+	return c.rwc.Close()
+}
+
+// WriteControl writes a close, ping or pong frame
+func (c *Conn) WriteControl(messageType int, data []byte) error {
+	if messageType != CloseMessage && messageType != PingMessage && messageType != PongMessage {
+		return errors.New("websocket: bad control message type " + strconv.Itoa(messageType))
+	}
+	if len(data) > maxControlPayloadSize {
+		return errors.New("websocket: control message too long")
+	}
+	return c.writeFrame(true, false, messageType, data)
+}
+
+// WriteMessage writes a message in one frame
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return c.WriteControl(messageType, data)
+	}
+	if c.compression == nil {
+		return c.writeFrame(true, false, messageType, data)
+	}
+
+	c.writeMu.Lock()
+	compressor := c.takeCompressor()
+	c.writeMu.Unlock()
+
+	payload, err := compressor.write(data)
+	if err == nil {
+		var rest []byte
+		rest, err = compressor.finish()
+		payload = append(payload, rest...)
+	}
+	c.putCompressor(compressor)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(true, true, messageType, payload)
+}
+
+// NextWriter returns a writer of a message, every Write is sent as a
+// fragment and Close finishes the message.
+func (c *Conn) NextWriter(messageType int) (io.WriteCloser, error) {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return nil, errors.New("websocket: bad data message type " + strconv.Itoa(messageType))
+	}
+
+	w := &messageWriter{conn: c, opcode: messageType}
+	if c.compression != nil {
+		c.writeMu.Lock()
+		w.compressor = c.takeCompressor()
+		c.writeMu.Unlock()
+	}
+	return w, nil
+}
+
+// takeCompressor is called with writeMu held
+func (c *Conn) takeCompressor() *compressor {
+	compressor := c.compressor
+	c.compressor = nil
+	if compressor == nil {
+		return newCompressor()
+	}
+	compressor.reset()
+	return compressor
+}
+
+func (c *Conn) putCompressor(compressor *compressor) {
+	c.writeMu.Lock()
+	c.compressor = compressor
+	c.writeMu.Unlock()
+}
+
+type messageWriter struct {
+	conn       *Conn
+	opcode     int
+	compressor *compressor
+	// the first frame is sent, the others are continuations
+	started bool
+	closed  bool
+}
+
+func (w *messageWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("websocket: write to closed message writer")
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	payload := p
+	if w.compressor != nil {
+		var err error
+		if payload, err = w.compressor.write(p); err != nil {
+			return 0, err
+		}
+	}
+	if err := w.writeFragment(false, payload); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *messageWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	var payload []byte
+	if w.compressor != nil {
+		var err error
+		payload, err = w.compressor.finish()
+		w.conn.putCompressor(w.compressor)
+		if err != nil {
+			return err
+		}
+	}
+	return w.writeFragment(true, payload)
+}
+
+func (w *messageWriter) writeFragment(final bool, payload []byte) error {
+	opcode := w.opcode
+	if w.started {
+		opcode = continuationFrame
+	}
+	// RSV1 marks the first frame of a compressed message
+	compressed := w.compressor != nil && !w.started
+	w.started = true
+	return w.conn.writeFrame(final, compressed, opcode, payload)
+}
+
+func (c *Conn) writeFrame(final, compressed bool, opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.writeErr != nil {
+		return c.writeErr
+	}
+	if c.closeSent {
+		return ErrCloseSent
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	b0 := byte(opcode)
+	if final {
+		b0 |= finalBit
+	}
+	if compressed {
+		b0 |= rsv1Bit
+	}
+	frame = append(frame, b0)
+
+	var b1 byte
+	if !c.server {
+		b1 = maskBit
+	}
+	switch length := len(payload); {
+	case length <= 125:
+		frame = append(frame, b1|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, b1|126, byte(length>>8), byte(length))
+	default:
+		frame = append(frame, b1|127)
+		var p [8]byte
+		binary.BigEndian.PutUint64(p[:], uint64(length))
+		frame = append(frame, p[:]...)
+	}
+
+	start := len(frame)
+	if c.server {
+		frame = append(frame, payload...)
+	} else {
+		// a client masks every frame
+		var key [4]byte
+		if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+			return err
+		}
+		frame = append(frame, key[:]...)
+		start = len(frame)
+		frame = append(frame, payload...)
+		maskBytes(key, frame[start:])
+	}
+
+	if _, err := c.rwc.Write(frame); err != nil {
+		c.writeErr = err
+		return err
+	}
+	if opcode == CloseMessage {
+		c.closeSent = true
+	}
+	return nil
+}
+
+func maskBytes(key [4]byte, p []byte) {
+	for i := range p {
+		p[i] ^= key[i&3]
+	}
+}
+
+// ReadMessage reads the next text or binary message, control frames are
+// handled on the way. Once the peer closed the connection a *CloseError is
+// returned.
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	if c.readErr != nil {
+		return 0, nil, c.readErr
+	}
+
+	compressed := false
+	for {
+		var final, rsv1 bool
+		var opcode int
+		var payload []byte
+		final, rsv1, opcode, payload, err = c.readFrame(int64(len(data)))
+		if err != nil {
+			return 0, nil, c.readFailed(err)
+		}
+
+		if opcode >= CloseMessage {
+			if err = c.handleControl(opcode, payload); err != nil {
+				return 0, nil, c.readFailed(err)
+			}
+			continue
+		}
+
+		switch {
+		case opcode == continuationFrame && messageType == 0:
+			return 0, nil, c.readFailed(protocolError("continuation without a message"))
+		case opcode != continuationFrame && messageType != 0:
+			return 0, nil, c.readFailed(protocolError("message inside a fragmented message"))
+		case rsv1 && (c.compression == nil || opcode == continuationFrame):
+			return 0, nil, c.readFailed(protocolError("unexpected RSV1"))
+		}
+
+		if opcode != continuationFrame {
+			messageType = opcode
+			compressed = rsv1
+		}
+		data = append(data, payload...)
+		if final {
+			break
+		}
+	}
+
+	if compressed {
+		data, err = c.decompressor.decompress(data, c.messageLimit(), c.compression.readNoContextTakeover)
+		if err != nil && err != errReadLimit {
+			err = protocolError("bad compressed message: " + err.Error())
+		}
+		if err != nil {
+			return 0, nil, c.readFailed(err)
+		}
+	}
+
+	if messageType == TextMessage && !utf8.Valid(data) {
+		return 0, nil, c.readFailed(errInvalidUTF8)
+	}
+	return messageType, data, nil
+}
+
+var errInvalidUTF8 = errors.New("websocket: invalid UTF-8 in text message")
+
+// readFrame reads a frame, read is the size of the message so far
+func (c *Conn) readFrame(read int64) (final, rsv1 bool, opcode int, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.reader, head[:]); err != nil {
+		return
+	}
+
+	final = head[0]&finalBit != 0
+	rsv1 = head[0]&rsv1Bit != 0
+	opcode = int(head[0] & 0x0f)
+	masked := head[1]&maskBit != 0
+	length := int64(head[1] & 0x7f)
+
+	if head[0]&rsvBits&^rsv1Bit != 0 {
+		err = protocolError("unexpected RSV2 or RSV3")
+		return
+	}
+	switch opcode {
+	case continuationFrame, TextMessage, BinaryMessage:
+	case CloseMessage, PingMessage, PongMessage:
+		if !final || length > maxControlPayloadSize || rsv1 {
+			err = protocolError("bad control frame")
+			return
+		}
+	default:
+		err = protocolError("unknown opcode " + strconv.Itoa(opcode))
+		return
+	}
+	// only a client masks its frames
+	if masked != c.server {
+		err = protocolError("bad frame masking")
+		return
+	}
+
+	switch length {
+	case 126:
+		var p [2]byte
+		if _, err = io.ReadFull(c.reader, p[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(p[:]))
+	case 127:
+		var p [8]byte
+		if _, err = io.ReadFull(c.reader, p[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(p[:]))
+		if length < 0 {
+			err = protocolError("bad frame length")
+			return
+		}
+	}
+
+	// length is up to 63 bits, compared so that it can not overflow
+	if limit := c.messageLimit(); opcode < CloseMessage && limit > 0 && length > limit-read {
+		err = errReadLimit
+		return
+	}
+
+	var key [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.reader, key[:]); err != nil {
+			return
+		}
+	}
+
+	payload, err = readPayload(c.reader, length)
+	if err != nil {
+		return
+	}
+	if masked {
+		maskBytes(key, payload)
+	}
+	return
+}
+
+// readPayload reads length bytes from r, a buffer for more than
+// readChunkSize bytes only grows with the data actually received
+func readPayload(r io.Reader, length int64) ([]byte, error) {
+	if length <= readChunkSize {
+		payload := make([]byte, length)
+		_, err := io.ReadFull(r, payload)
+		return payload, err
+	}
+
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r, length); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
+
+func (c *Conn) handleControl(opcode int, payload []byte) error {
+	switch opcode {
+	case PingMessage:
+		return c.pingHandler(payload)
+	case PongMessage:
+		return c.pongHandler(payload)
+	}
+
+	closeErr := &CloseError{Code: CloseNoStatusReceived}
+	switch {
+	case len(payload) == 1:
+		return protocolError("bad close frame")
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+		if !validCloseCode(closeErr.Code) {
+			return protocolError("bad close code " + strconv.Itoa(closeErr.Code))
+		}
+		if !utf8.Valid(payload[2:]) {
+			return errInvalidUTF8
+		}
+	}
+
+	// echo the close, it fails when the close was sent already
+	c.WriteControl(CloseMessage, FormatCloseMessage(closeErr.Code, ""))
+	return closeErr
+}
+
+// validCloseCode reports whether code may be sent in a close frame
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1011:
+		return true
+	case code >= 3000 && code <= 4999:
+		return true
+	}
+	return false
+}
+
+// readFailed ends reading with err, a violation of the protocol closes the
+// connection with the matching close code.
+func (c *Conn) readFailed(err error) error {
+	switch err.(type) {
+	case protocolError:
+		c.WriteControl(CloseMessage, FormatCloseMessage(CloseProtocolError, ""))
+	}
+	switch err {
+	case io.EOF, io.ErrUnexpectedEOF:
+		err = &CloseError{Code: CloseAbnormalClosure, Text: err.Error()}
+	case errReadLimit:
+		c.WriteControl(CloseMessage, FormatCloseMessage(CloseMessageTooBig, ""))
+	case errInvalidUTF8:
+		c.WriteControl(CloseMessage, FormatCloseMessage(CloseInvalidFramePayloadData, ""))
+	}
+	c.readErr = err
+	return err
+}
@@ -0,0 +1,243 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// the requests the proxy of the environment gets
+var proxied = make(chan string, 1)
+
+func TestMain(m *testing.M) {
+	// ProxyFromEnvironment reads the environment once, so it is set before
+	// any test. The other tests dial loopback addresses which it never proxies.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case proxied <- r.Method + " " + r.Host:
+		default:
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	os.Setenv("HTTP_PROXY", proxy.URL)
+	os.Unsetenv("NO_PROXY")
+	os.Unsetenv("no_proxy")
+
+	code := m.Run()
+	proxy.Close()
+	os.Exit(code)
+}
+
+// hijackedConn reads what the server buffered before the hijack
+type hijackedConn struct {
+	reader *bufio.Reader
+	conn   net.Conn
+}
+
+func (c *hijackedConn) Read(p []byte) (int, error)  { return c.reader.Read(p) }
+func (c *hijackedConn) Write(p []byte) (int, error) { return c.conn.Write(p) }
+func (c *hijackedConn) Close() error                { return c.conn.Close() }
+
+// newEchoServer echoes the messages of a client, a ping message makes it
+// ping the client first.
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Sec-WebSocket-Version") != "13" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		netConn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer netConn.Close()
+
+		var compression *compressionConfig
+		response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n"
+		if strings.Contains(r.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate") {
+			compression = &compressionConfig{readNoContextTakeover: true}
+			response += "Sec-WebSocket-Extensions: permessage-deflate; client_no_context_takeover\r\n"
+		}
+		if r.Header.Get("Sec-WebSocket-Protocol") != "" {
+			response += "Sec-WebSocket-Protocol: echo\r\n"
+		}
+		rw.WriteString(response + "\r\n")
+		rw.Flush()
+
+		conn := newConn(&hijackedConn{rw.Reader, netConn}, true, compression)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "ping" {
+				conn.WriteControl(PingMessage, []byte("server"))
+			}
+			if err = conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestConn(t *testing.T) {
+	ts := newEchoServer()
+	defer ts.Close()
+
+	for _, compress := range []bool{false, true} {
+		dialer := &Dialer{Subprotocols: []string{"echo"}, EnableCompression: compress}
+		conn, response, err := dialer.Dial(context.Background(), "ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("compress %v: %v", compress, err)
+		}
+		if response.StatusCode != http.StatusSwitchingProtocols || conn.Subprotocol() != "echo" {
+			t.Fatalf("compress %v: handshake should select echo and is %d %q.", compress, response.StatusCode, conn.Subprotocol())
+		}
+		if (conn.compression != nil) != compress {
+			t.Fatalf("compress %v: compression should be negotiated.", compress)
+		}
+
+		pong := make(chan string, 1)
+		conn.SetPingHandler(func(data []byte) error {
+			pong <- string(data)
+			return conn.WriteControl(PongMessage, data)
+		})
+
+		large := bytes.Repeat([]byte("websocket "), 20000)
+		for _, message := range [][]byte{[]byte("hello"), large, []byte("ping")} {
+			if err = conn.WriteMessage(TextMessage, message); err != nil {
+				t.Fatal(err)
+			}
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if messageType != TextMessage || !bytes.Equal(data, message) {
+				t.Errorf("compress %v: echo of %d bytes is %d bytes.", compress, len(message), len(data))
+			}
+		}
+		if data := <-pong; data != "server" {
+			t.Errorf("ping should be server and is %q.", data)
+		}
+
+		// a message in fragments
+		w, _ := conn.NextWriter(BinaryMessage)
+		w.Write([]byte("frag"))
+		w.Write([]byte("ments"))
+		w.Close()
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if messageType != BinaryMessage || string(data) != "fragments" {
+			t.Errorf("compress %v: fragmented echo is %d %q.", compress, messageType, data)
+		}
+
+		// the server echoes the close
+		conn.WriteControl(CloseMessage, FormatCloseMessage(CloseNormalClosure, "bye"))
+		_, _, err = conn.ReadMessage()
+		if closeErr, ok := err.(*CloseError); !ok || closeErr.Code != CloseNormalClosure {
+			t.Errorf("compress %v: read after close should be a normal closure and is %v.", compress, err)
+		}
+		conn.Close()
+	}
+}
+
+func TestDialBadHandshake(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	}))
+	defer ts.Close()
+
+	_, response, err := DefaultDialer.Dial(context.Background(), "ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != ErrBadHandshake {
+		t.Fatalf("dial should fail with ErrBadHandshake and is %v.", err)
+	}
+	if response == nil || response.StatusCode != http.StatusForbidden {
+		t.Fatalf("the response of the handshake should be returned.")
+	}
+}
+
+func TestDialProxyFromEnvironment(t *testing.T) {
+	if _, _, err := DefaultDialer.Dial(context.Background(), "ws://websocket.test/", nil); err == nil {
+		t.Error("dial should fail with the refused tunnel.")
+	}
+
+	select {
+	case request := <-proxied:
+		if request != "CONNECT websocket.test:80" {
+			t.Errorf("proxy should tunnel the handshake and gets %s.", request)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("handshake should be sent to the proxy of HTTP_PROXY.")
+	}
+}
+
+func TestCompression(t *testing.T) {
+	c := newCompressor()
+	var d decompressor
+	for _, message := range []string{"", "a", strings.Repeat("abc", 10000)} {
+		c.reset()
+		first, _ := c.write([]byte(message))
+		rest, _ := c.finish()
+		data, err := d.decompress(append(first, rest...), 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != message {
+			t.Errorf("message of %d bytes is %d bytes after compression.", len(message), len(data))
+		}
+	}
+
+	c.reset()
+	payload, _ := c.write(bytes.Repeat([]byte("a"), 1000))
+	rest, _ := c.finish()
+	if _, err := d.decompress(append(payload, rest...), 100, true); err != errReadLimit {
+		t.Errorf("decompress should stop at the limit and is %v.", err)
+	}
+}
+
+// frameConn reads the frames of a peer from a buffer and drops the writes
+type frameConn struct {
+	bytes.Reader
+}
+
+func (c *frameConn) Write(p []byte) (int, error) { return len(p), nil }
+func (c *frameConn) Close() error                { return nil }
+
+func TestReadFrameLength(t *testing.T) {
+	for _, test := range []struct {
+		limit  int64
+		header []byte
+		err    error
+	}{
+		// a 63-bit length must neither panic nor allocate
+		{header: []byte{0x82, 127, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, err: errReadLimit},
+		// the default limit applies without SetReadLimit
+		{header: []byte{0x82, 127, 0, 0, 0, 0, 0x02, 0, 0, 1}, err: errReadLimit},
+		{limit: 100, header: []byte{0x82, 126, 0, 101}, err: errReadLimit},
+		// without a limit the payload is only read as it arrives
+		{limit: -1, header: []byte{0x82, 127, 0, 0, 0x01, 0, 0, 0, 0, 0}, err: &CloseError{Code: CloseAbnormalClosure, Text: io.ErrUnexpectedEOF.Error()}},
+	} {
+		data := append(append([]byte(nil), test.header...), make([]byte, 1<<20)...)
+		conn := newConn(&frameConn{*bytes.NewReader(data)}, false, nil)
+		conn.SetReadLimit(test.limit)
+
+		_, _, err := conn.ReadMessage()
+		if !reflect.DeepEqual(err, test.err) {
+			t.Errorf("limit %d header %x: error should be %v and is %v.", test.limit, test.header, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package websocket is a WebSocket client on top of curl.Transport, the
+// handshake is sent like any other request so the proxy, TLS and resolve
+// settings of the Transport apply.
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/YangSen-qn/go-curl/v2/curl"
+)
+
+// the GUID of RFC 6455 the accept key is derived with
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var ErrBadHandshake = errors.New("websocket: bad handshake")
+
+type Dialer struct {
+	// Transport sends the handshake, it has to return the upgraded
+	// connection as the body of the 101 response like curl.Transport does.
+	// A curl.Transport using HTTP/1.1 and the proxy of the environment if nil.
+	Transport http.RoundTripper
+
+	// offered with Sec-WebSocket-Protocol
+	Subprotocols []string
+	// offer permessage-deflate
+	EnableCompression bool
+}
+
+var DefaultDialer = &Dialer{}
+
+// defaultTransport sends the handshake of a Dialer without Transport, like
+// http.DefaultTransport it uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+var defaultTransport http.RoundTripper = &curl.Transport{
+	Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+	Protocol:  curl.ProtocolHTTP1,
+}
+
+// Dial opens a WebSocket connection to a ws or wss URL. The response of a
+// failed handshake is returned with ErrBadHandshake.
+func (d *Dialer) Dial(ctx context.Context, urlStr string, header http.Header) (*Conn, *http.Response, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch u.Scheme {
+	case "ws":
+		u.Scheme = "http"
+	case "wss":
+		u.Scheme = "https"
+	default:
+		return nil, nil, fmt.Errorf("websocket: unsupported scheme %q", u.Scheme)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, values := range header {
+		switch http.CanonicalHeaderKey(key) {
+		case "Upgrade", "Connection", "Sec-Websocket-Key", "Sec-Websocket-Version", "Sec-Websocket-Extensions":
+			return nil, nil, fmt.Errorf("websocket: duplicate header %s", key)
+		case "Sec-Websocket-Protocol":
+			return nil, nil, errors.New("websocket: use Dialer.Subprotocols for Sec-WebSocket-Protocol")
+		}
+		request.Header[key] = values
+	}
+
+	challengeKey, err := newChallengeKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", challengeKey)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	if len(d.Subprotocols) > 0 {
+		request.Header.Set("Sec-WebSocket-Protocol", strings.Join(d.Subprotocols, ", "))
+	}
+	if d.EnableCompression {
+		request.Header.Set("Sec-WebSocket-Extensions", compressionOffer)
+	}
+
+	transport := d.Transport
+	if transport == nil {
+		transport = defaultTransport
+	}
+
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.StatusCode != http.StatusSwitchingProtocols ||
+		!headerContainsToken(response.Header, "Upgrade", "websocket") ||
+		!headerContainsToken(response.Header, "Connection", "upgrade") ||
+		response.Header.Get("Sec-WebSocket-Accept") != acceptKey(challengeKey) {
+		// keep a part of the body for the caller
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+		response.Body.Close()
+		response.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+		return nil, response, ErrBadHandshake
+	}
+
+	rwc, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		response.Body.Close()
+		return nil, response, errors.New("websocket: the transport did not return a writable body")
+	}
+
+	subprotocol := response.Header.Get("Sec-WebSocket-Protocol")
+	if subprotocol != "" && !containsString(d.Subprotocols, subprotocol) {
+		rwc.Close()
+		return nil, response, ErrBadHandshake
+	}
+
+	compression, err := negotiateCompression(response.Header, d.EnableCompression)
+	if err != nil {
+		rwc.Close()
+		return nil, response, err
+	}
+
+	response.Body = ioutil.NopCloser(strings.NewReader(""))
+	conn := newConn(rwc, false, compression)
+	conn.subprotocol = subprotocol
+	return conn, response, nil
+}
+
+func newChallengeKey() (string, error) {
+	p := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, p); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(p), nil
+}
+
+// acceptKey is the Sec-WebSocket-Accept of challengeKey
+func acceptKey(challengeKey string) string {
+	h := sha1.New()
+	h.Write([]byte(challengeKey + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerContainsToken reports whether the comma separated values of key
+// contain token, ignoring case
+func headerContainsToken(header http.Header, key, token string) bool {
+	for _, value := range header.Values(key) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -31,6 +31,15 @@ void *return_read_function() {
     return (void *)&read_function;
 }
 
+/* for OPT_SEEKFUNCTION */
+int seek_function(void *ctx, curl_off_t offset, int origin) {
+	return goCallSeekFunction(offset, origin, ctx);
+}
+
+void *return_seek_function() {
+    return (void *)&seek_function;
+}
+
 
 /* for OPT_PROGRESSFUNCTION */
 int progress_function(void *ctx, double dltotal, double dlnow, double ultotal, double ulnow) {
@@ -40,3 +49,22 @@ int progress_function(void *ctx, double dltotal, double dlnow, double ultotal, d
 void *return_progress_function() {
     return (void *)progress_function;
 }
+
+/* for OPT_XFERINFOFUNCTION */
+int xferinfo_function(void *ctx, curl_off_t dltotal, curl_off_t dlnow, curl_off_t ultotal, curl_off_t ulnow) {
+	return goCallXferinfoFunction(dltotal, dlnow, ultotal, ulnow, ctx);
+}
+
+void *return_xferinfo_function() {
+    return (void *)&xferinfo_function;
+}
+
+/* for OPT_DEBUGFUNCTION */
+int debug_function(CURL *handle, curl_infotype type, char *data, size_t size, void *ctx) {
+	goCallDebugFunction(type, data, size, ctx);
+	return 0;
+}
+
+void *return_debug_function() {
+    return (void *)&debug_function;
+}
@@ -46,15 +46,47 @@ func goCallProgressFunction(dltotal, dlnow, ultotal, ulnow C.double, ctx unsafe.
 	return 1
 }
 
+//export goCallXferinfoFunction
+func goCallXferinfoFunction(dltotal, dlnow, ultotal, ulnow C.curl_off_t, ctx unsafe.Pointer) int {
+	curl := context_map.Get(uintptr(ctx))
+	if curl == nil {
+		return 1
+	}
+	if err := (*curl.xferinfoFunction)(int64(dltotal), int64(dlnow), int64(ultotal), int64(ulnow), curl.progressData); err != nil {
+		curl.xferinfoErr = err
+		return 1
+	}
+	return 0
+}
+
 //export goCallReadFunction
 func goCallReadFunction(ptr *C.char, size C.size_t, ctx unsafe.Pointer) uintptr {
 	curl := context_map.Get(uintptr(ctx))
-	buf := C.GoBytes(unsafe.Pointer(ptr), C.int(size))
+	if curl == nil {
+		return C.CURL_READFUNC_ABORT
+	}
+	buf := make([]byte, int(size))
 	ret := (*curl.readFunction)(buf, curl.readData)
-	str := C.CString(string(buf))
-	defer C.free(unsafe.Pointer(str))
-	if curl != nil && C.memcpy(unsafe.Pointer(ptr), unsafe.Pointer(str), C.size_t(ret)) == nil {
-		panic("read_callback memcpy error!")
+	// READFUNC_ABORT and READFUNC_PAUSE are larger than the buffer
+	if ret > 0 && ret <= len(buf) {
+		C.memcpy(unsafe.Pointer(ptr), unsafe.Pointer(&buf[0]), C.size_t(ret))
 	}
 	return uintptr(ret)
 }
+
+//export goCallSeekFunction
+func goCallSeekFunction(offset C.curl_off_t, origin C.int, ctx unsafe.Pointer) C.int {
+	curl := context_map.Get(uintptr(ctx))
+	if curl == nil {
+		return C.CURL_SEEKFUNC_FAIL
+	}
+	return C.int((*curl.seekFunction)(int64(offset), int(origin), curl.seekData))
+}
+
+//export goCallDebugFunction
+func goCallDebugFunction(debugType C.curl_infotype, ptr *C.char, size C.size_t, ctx unsafe.Pointer) {
+	curl := context_map.Get(uintptr(ctx))
+	if curl != nil && curl.debugFunction != nil {
+		(*curl.debugFunction)(DebugType(debugType), C.GoBytes(unsafe.Pointer(ptr), C.int(size)), curl.debugData)
+	}
+}
@@ -2,5 +2,8 @@
 void *return_header_function();
 void *return_write_function();
 void *return_read_function();
+void *return_seek_function();
 
 void *return_progress_function();
+void *return_xferinfo_function();
+void *return_debug_function();
@@ -29,12 +29,22 @@ const (
 
 // for multi.Setopt(flag, ...)
 const (
-	MOPT_SOCKETFUNCTION = C.CURLMOPT_SOCKETFUNCTION
-	MOPT_SOCKETDATA     = C.CURLMOPT_SOCKETDATA
-	MOPT_PIPELINING     = C.CURLMOPT_PIPELINING
-	MOPT_TIMERFUNCTION  = C.CURLMOPT_TIMERFUNCTION
-	MOPT_TIMERDATA      = C.CURLMOPT_TIMERDATA
-	MOPT_MAXCONNECTS    = C.CURLMOPT_MAXCONNECTS
+	MOPT_SOCKETFUNCTION         = C.CURLMOPT_SOCKETFUNCTION
+	MOPT_SOCKETDATA             = C.CURLMOPT_SOCKETDATA
+	MOPT_PIPELINING             = C.CURLMOPT_PIPELINING
+	MOPT_TIMERFUNCTION          = C.CURLMOPT_TIMERFUNCTION
+	MOPT_TIMERDATA              = C.CURLMOPT_TIMERDATA
+	MOPT_MAXCONNECTS            = C.CURLMOPT_MAXCONNECTS
+	MOPT_MAX_HOST_CONNECTIONS   = C.CURLMOPT_MAX_HOST_CONNECTIONS
+	MOPT_MAX_TOTAL_CONNECTIONS  = C.CURLMOPT_MAX_TOTAL_CONNECTIONS
+	MOPT_MAX_CONCURRENT_STREAMS = C.CURLMOPT_MAX_CONCURRENT_STREAMS
+)
+
+// for multi.Setopt(MOPT_PIPELINING, flag)
+const (
+	PIPE_NOTHING   = C.CURLPIPE_NOTHING
+	PIPE_HTTP1     = C.CURLPIPE_HTTP1
+	PIPE_MULTIPLEX = C.CURLPIPE_MULTIPLEX
 )
 
 // CURLSHcode
@@ -94,12 +104,50 @@ const (
 	VERSION_NTLM_WB      = C.CURL_VERSION_NTLM_WB
 )
 
+// flags of a CurlBlob
+const (
+	BLOB_COPY   = C.CURL_BLOB_COPY
+	BLOB_NOCOPY = C.CURL_BLOB_NOCOPY
+)
+
+// for TLSSessionInfo.Backend
+const (
+	SSLBACKEND_NONE            = C.CURLSSLBACKEND_NONE
+	SSLBACKEND_OPENSSL         = C.CURLSSLBACKEND_OPENSSL
+	SSLBACKEND_GNUTLS          = C.CURLSSLBACKEND_GNUTLS
+	SSLBACKEND_NSS             = C.CURLSSLBACKEND_NSS
+	SSLBACKEND_GSKIT           = C.CURLSSLBACKEND_GSKIT
+	SSLBACKEND_WOLFSSL         = C.CURLSSLBACKEND_WOLFSSL
+	SSLBACKEND_SCHANNEL        = C.CURLSSLBACKEND_SCHANNEL
+	SSLBACKEND_SECURETRANSPORT = C.CURLSSLBACKEND_SECURETRANSPORT
+	SSLBACKEND_MBEDTLS         = C.CURLSSLBACKEND_MBEDTLS
+	SSLBACKEND_BEARSSL         = C.CURLSSLBACKEND_BEARSSL
+)
+
 // for OPT_READFUNCTION, return a int flag
 const (
 	READFUNC_ABORT = C.CURL_READFUNC_ABORT
 	READFUNC_PAUSE = C.CURL_READFUNC_PAUSE
 )
 
+// for OPT_DEBUGFUNCTION, the type of the data
+const (
+	DEBUG_TEXT         DebugType = C.CURLINFO_TEXT
+	DEBUG_HEADER_IN    DebugType = C.CURLINFO_HEADER_IN
+	DEBUG_HEADER_OUT   DebugType = C.CURLINFO_HEADER_OUT
+	DEBUG_DATA_IN      DebugType = C.CURLINFO_DATA_IN
+	DEBUG_DATA_OUT     DebugType = C.CURLINFO_DATA_OUT
+	DEBUG_SSL_DATA_IN  DebugType = C.CURLINFO_SSL_DATA_IN
+	DEBUG_SSL_DATA_OUT DebugType = C.CURLINFO_SSL_DATA_OUT
+)
+
+// for OPT_SEEKFUNCTION, return a int flag
+const (
+	SEEKFUNC_OK       = C.CURL_SEEKFUNC_OK
+	SEEKFUNC_FAIL     = C.CURL_SEEKFUNC_FAIL
+	SEEKFUNC_CANTSEEK = C.CURL_SEEKFUNC_CANTSEEK
+)
+
 // for easy.Setopt(OPT_HTTP_VERSION, flag)
 const (
 	HTTP_VERSION_NONE = C.CURL_HTTP_VERSION_NONE
@@ -107,12 +155,17 @@ const (
 	HTTP_VERSION_1_1  = C.CURL_HTTP_VERSION_1_1
 	HTTP_VERSION_2    = C.CURL_HTTP_VERSION_2_0
 	HTTP_VERSION_3    = C.CURL_HTTP_VERSION_3
+	// HTTP/2 over TLS only, HTTP/1.1 for http URLs
+	HTTP_VERSION_2TLS = C.CURL_HTTP_VERSION_2TLS
+	// HTTP/2 without the HTTP/1.1 upgrade, also known as h2c
+	HTTP_VERSION_2_PRIOR_KNOWLEDGE = C.CURL_HTTP_VERSION_2_PRIOR_KNOWLEDGE
 )
 
 // for easy.Setopt(OPT_PROXYTYPE, flag)
 const (
 	PROXY_HTTP     = C.CURLPROXY_HTTP     /* added in 7.10, new in 7.19.4 default is to use CONNECT HTTP/1.1 */
 	PROXY_HTTP_1_0 = C.CURLPROXY_HTTP_1_0 /* added in 7.19.4, force to use CONNECT HTTP/1.0  */
+	PROXY_HTTPS    = C.CURLPROXY_HTTPS    /* added in 7.52.0 */
 	PROXY_SOCKS4   = C.CURLPROXY_SOCKS4   /* support added in 7.15.2, enum existed already in 7.10 */
 	PROXY_SOCKS5   = C.CURLPROXY_SOCKS5   /* added in 7.10 */
 	PROXY_SOCKS4A  = C.CURLPROXY_SOCKS4A  /* added in 7.18.0 */
@@ -120,12 +173,29 @@ const (
 	PROXY_SOCKS5_HOSTNAME = C.CURLPROXY_SOCKS5_HOSTNAME
 )
 
+// for easy.Setopt(OPT_HEADEROPT, flag)
+const (
+	HEADER_UNIFIED  = C.CURLHEADER_UNIFIED
+	HEADER_SEPARATE = C.CURLHEADER_SEPARATE
+)
+
 // for easy.Setopt(OPT_SSLVERSION, flag)
 const (
 	SSLVERSION_DEFAULT = C.CURL_SSLVERSION_DEFAULT
 	SSLVERSION_TLSv1   = C.CURL_SSLVERSION_TLSv1
 	SSLVERSION_SSLv2   = C.CURL_SSLVERSION_SSLv2
 	SSLVERSION_SSLv3   = C.CURL_SSLVERSION_SSLv3
+	SSLVERSION_TLSv1_0 = C.CURL_SSLVERSION_TLSv1_0
+	SSLVERSION_TLSv1_1 = C.CURL_SSLVERSION_TLSv1_1
+	SSLVERSION_TLSv1_2 = C.CURL_SSLVERSION_TLSv1_2
+	SSLVERSION_TLSv1_3 = C.CURL_SSLVERSION_TLSv1_3
+
+	// or'ed with one of the above to limit the maximum version
+	SSLVERSION_MAX_DEFAULT = C.CURL_SSLVERSION_MAX_DEFAULT
+	SSLVERSION_MAX_TLSv1_0 = C.CURL_SSLVERSION_MAX_TLSv1_0
+	SSLVERSION_MAX_TLSv1_1 = C.CURL_SSLVERSION_MAX_TLSv1_1
+	SSLVERSION_MAX_TLSv1_2 = C.CURL_SSLVERSION_MAX_TLSv1_2
+	SSLVERSION_MAX_TLSv1_3 = C.CURL_SSLVERSION_MAX_TLSv1_3
 )
 
 // for easy.Setopt(OPT_TIMECONDITION, flag)
@@ -404,6 +404,17 @@ const (
 	OPT_SSLCERTPASSWD             = C.CURLOPT_SSLCERTPASSWD
 	OPT_KRB4LEVEL                 = C.CURLOPT_KRB4LEVEL
 	OPT_RTSPHEADER                = C.CURLOPT_RTSPHEADER
+	OPT_SSLCERT_BLOB              = C.CURLOPT_SSLCERT_BLOB
+	OPT_SSLKEY_BLOB               = C.CURLOPT_SSLKEY_BLOB
+	OPT_PROXY_SSLCERT_BLOB        = C.CURLOPT_PROXY_SSLCERT_BLOB
+	OPT_PROXY_SSLKEY_BLOB         = C.CURLOPT_PROXY_SSLKEY_BLOB
+	OPT_ISSUERCERT_BLOB           = C.CURLOPT_ISSUERCERT_BLOB
+	OPT_PROXY_ISSUERCERT          = C.CURLOPT_PROXY_ISSUERCERT
+	OPT_PROXY_ISSUERCERT_BLOB     = C.CURLOPT_PROXY_ISSUERCERT_BLOB
+	OPT_DNS_SHUFFLE_ADDRESSES     = C.CURLOPT_DNS_SHUFFLE_ADDRESSES
+	OPT_DOH_URL                   = C.CURLOPT_DOH_URL
+	OPT_HAPPY_EYEBALLS_TIMEOUT_MS = C.CURLOPT_HAPPY_EYEBALLS_TIMEOUT_MS
+	OPT_MAXAGE_CONN               = C.CURLOPT_MAXAGE_CONN
 )
 
 // easy.Getinfo(flag)
@@ -427,6 +438,7 @@ const (
 	INFO_REQUEST_SIZE         = C.CURLINFO_REQUEST_SIZE
 	INFO_SSL_VERIFYRESULT     = C.CURLINFO_SSL_VERIFYRESULT
 	INFO_FILETIME             = C.CURLINFO_FILETIME
+	INFO_FILETIME_T           = C.CURLINFO_FILETIME_T
 	INFO_CONTENT_LENGTH_DOWNLOAD = C.CURLINFO_CONTENT_LENGTH_DOWNLOAD
 	INFO_CONTENT_LENGTH_DOWNLOAD_T = C.CURLINFO_CONTENT_LENGTH_DOWNLOAD_T
 	INFO_CONTENT_LENGTH_UPLOAD = C.CURLINFO_CONTENT_LENGTH_UPLOAD
@@ -466,6 +478,14 @@ const (
 	INFO_SCHEME               = C.CURLINFO_SCHEME
 	INFO_LASTONE              = C.CURLINFO_LASTONE
 	INFO_HTTP_CODE            = C.CURLINFO_HTTP_CODE
+	INFO_TOTAL_TIME_T         = C.CURLINFO_TOTAL_TIME_T
+	INFO_NAMELOOKUP_TIME_T    = C.CURLINFO_NAMELOOKUP_TIME_T
+	INFO_CONNECT_TIME_T       = C.CURLINFO_CONNECT_TIME_T
+	INFO_PRETRANSFER_TIME_T   = C.CURLINFO_PRETRANSFER_TIME_T
+	INFO_STARTTRANSFER_TIME_T = C.CURLINFO_STARTTRANSFER_TIME_T
+	INFO_REDIRECT_TIME_T      = C.CURLINFO_REDIRECT_TIME_T
+	INFO_APPCONNECT_TIME_T    = C.CURLINFO_APPCONNECT_TIME_T
+	INFO_RETRY_AFTER          = C.CURLINFO_RETRY_AFTER
 )
 
 // Auth
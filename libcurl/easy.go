@@ -1,7 +1,10 @@
 package libcurl
 
 /*
+#include <errno.h>
+#include <poll.h>
 #include <stdlib.h>
+#include <string.h>
 #include "./include/curl.h"
 #include "callback.h"
 
@@ -20,6 +23,45 @@ static CURLcode curl_easy_setopt_pointer(CURL *handle, CURLoption option, void *
 static CURLcode curl_easy_setopt_off_t(CURL *handle, CURLoption option, off_t parameter) {
   return curl_easy_setopt(handle, option, parameter);
 }
+static CURLcode curl_easy_setopt_blob(CURL *handle, CURLoption option, void *data, size_t len) {
+  struct curl_blob blob;
+  blob.data = data;
+  blob.len = len;
+  blob.flags = CURL_BLOB_COPY;
+  return curl_easy_setopt(handle, option, &blob);
+}
+
+static struct curl_blob *curl_blob_new(void *data, size_t len) {
+  struct curl_blob *blob = malloc(sizeof(struct curl_blob));
+  if(!blob)
+    return NULL;
+  blob->data = malloc(len ? len : 1);
+  if(!blob->data) {
+    free(blob);
+    return NULL;
+  }
+  if(len)
+    memcpy(blob->data, data, len);
+  blob->len = len;
+  blob->flags = CURL_BLOB_NOCOPY;
+  return blob;
+}
+static void curl_blob_free(struct curl_blob *blob) {
+  free(blob->data);
+  free(blob);
+}
+
+static int curl_wait_socket(curl_socket_t sock, int for_send, int timeout_ms) {
+  struct pollfd pfd;
+  int ret;
+  pfd.fd = sock;
+  pfd.events = for_send ? POLLOUT : POLLIN;
+  pfd.revents = 0;
+  ret = poll(&pfd, 1, timeout_ms);
+  if(ret < 0 && errno == EINTR)
+    return 0;
+  return ret;
+}
 
 static CURLcode curl_easy_getinfo_string(CURL *curl, CURLINFO info, char **p) {
  return curl_easy_getinfo(curl, info, p);
@@ -27,12 +69,30 @@ static CURLcode curl_easy_getinfo_string(CURL *curl, CURLINFO info, char **p) {
 static CURLcode curl_easy_getinfo_long(CURL *curl, CURLINFO info, long *p) {
  return curl_easy_getinfo(curl, info, p);
 }
+static CURLcode curl_easy_getinfo_off_t(CURL *curl, CURLINFO info, curl_off_t *p) {
+ return curl_easy_getinfo(curl, info, p);
+}
+static CURLcode curl_easy_getinfo_socket(CURL *curl, CURLINFO info, curl_socket_t *p) {
+ return curl_easy_getinfo(curl, info, p);
+}
 static CURLcode curl_easy_getinfo_double(CURL *curl, CURLINFO info, double *p) {
  return curl_easy_getinfo(curl, info, p);
 }
 static CURLcode curl_easy_getinfo_slist(CURL *curl, CURLINFO info, struct curl_slist **p) {
  return curl_easy_getinfo(curl, info, p);
 }
+static CURLcode curl_easy_getinfo_pointer(CURL *curl, CURLINFO info, void **p) {
+ return curl_easy_getinfo(curl, info, p);
+}
+static CURLcode curl_easy_getinfo_tlssession(CURL *curl, CURLINFO info, struct curl_tlssessioninfo **p) {
+ return curl_easy_getinfo(curl, info, p);
+}
+static CURLcode curl_easy_getinfo_certinfo(CURL *curl, struct curl_certinfo **p) {
+ return curl_easy_getinfo(curl, CURLINFO_CERTINFO, p);
+}
+static struct curl_slist *certinfo_index(struct curl_certinfo *p, int i) {
+ return p->certinfo[i];
+}
 
 static CURLFORMcode curl_formadd_name_content_length(
     struct curl_httppost **httppost, struct curl_httppost **last_post, char *name, char *content, int length) {
@@ -82,6 +142,25 @@ func FreeCurlString(s CurlString) {
 	C.free(unsafe.Pointer(s))
 }
 
+// CurlBlob is a blob in C memory which libcurl uses without a copy
+// (BLOB_NOCOPY), it must not be freed while a handle it is set on is used.
+// A []byte is copied by libcurl instead.
+type CurlBlob *C.struct_curl_blob
+
+func NewCurlBlob(data []byte) CurlBlob {
+	var ptr unsafe.Pointer
+	if len(data) > 0 {
+		ptr = unsafe.Pointer(&data[0])
+	}
+	return CurlBlob(C.curl_blob_new(ptr, C.size_t(len(data))))
+}
+
+func FreeCurlBlob(b CurlBlob) {
+	if b != nil {
+		C.curl_blob_free(b)
+	}
+}
+
 func (e CurlError) Error() string {
 	// ret is const char*, no need to free
 	ret := C.curl_easy_strerror(C.CURLcode(e))
@@ -101,10 +180,15 @@ type CURL struct {
 	// callback functions, bool ret means ok or not
 	headerFunction, writeFunction *func([]byte, interface{}) bool
 	readFunction                  *func([]byte, interface{}) int // return num of bytes writed to buf
+	seekFunction                  *func(int64, int, interface{}) int // return SEEKFUNC_*
 	progressFunction              *func(float64, float64, float64, float64, interface{}) bool
 	fnmatchFunction               *func(string, string, interface{}) int
+	debugFunction                 *func(DebugType, []byte, interface{})
+	xferinfoFunction              *func(int64, int64, int64, int64, interface{}) error
+	// the error the xferinfo function aborted the transfer with
+	xferinfoErr error
 	// callback datas
-	headerData, writeData, readData, progressData, fnmatchData interface{}
+	headerData, writeData, readData, seekData, progressData, fnmatchData, debugData interface{}
 	// list of C allocs
 	mallocAllocs []*C.char
 }
@@ -166,7 +250,11 @@ func (curl *CURL) Cleanup() {
 }
 
 // curl_easy_setopt - set options for a curl easy handle
-// WARNING: a function pointer is &fun, but function addr is reflect.ValueOf(fun).Pointer()
+// Setopt takes the value of the typed setters of an option, an integer or
+// a bool for a long or off_t, a string or CurlString for a string, a
+// []string or []CurlString for a list, a []byte or CurlBlob for a blob and
+// the callbacks of the Set*Function setters. Any other value is an
+// *OptionError.
 func (curl *CURL) Setopt(opt int, param interface{}) error {
 	p := curl.handle
 	if param == nil {
@@ -178,7 +266,10 @@ func (curl *CURL) Setopt(opt int, param interface{}) error {
 	case opt == OPT_READDATA: // OPT_INFILE
 		curl.readData = param
 		return nil
-	case opt == OPT_PROGRESSDATA:
+	case opt == OPT_SEEKDATA:
+		curl.seekData = param
+		return nil
+	case opt == OPT_PROGRESSDATA: // also known as OPT_XFERINFODATA
 		curl.progressData = param
 		return nil
 	case opt == OPT_HEADERDATA: // also known as OPT_WRITEHEADER
@@ -187,161 +278,200 @@ func (curl *CURL) Setopt(opt int, param interface{}) error {
 	case opt == OPT_WRITEDATA: // OPT_FILE
 		curl.writeData = param
 		return nil
+	case opt == OPT_DEBUGDATA:
+		curl.debugData = param
+		return nil
 
 	case opt == OPT_READFUNCTION:
-		fun := param.(func([]byte, interface{}) int)
-		curl.readFunction = &fun
-
-		ptr := C.return_read_function()
-		if err := newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt), ptr)); err == nil {
-			return newCurlError(C.curl_easy_setopt_pointer(p, OPT_READDATA, unsafe.Pointer(curl.handle)))
-		} else {
-			return err
+		if fun, ok := param.(func([]byte, interface{}) int); ok {
+			return curl.SetReadFunction(fun)
+		}
+
+	case opt == OPT_SEEKFUNCTION:
+		if fun, ok := param.(func(int64, int, interface{}) int); ok {
+			return curl.SetSeekFunction(fun)
 		}
 
 	case opt == OPT_PROGRESSFUNCTION:
-		fun := param.(func(float64, float64, float64, float64, interface{}) bool)
-		curl.progressFunction = &fun
-
-		ptr := C.return_progress_function()
-		if err := newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt), ptr)); err == nil {
-			return newCurlError(C.curl_easy_setopt_pointer(p, OPT_PROGRESSDATA, unsafe.Pointer(curl.handle)))
-		} else {
-			return err
+		if fun, ok := param.(func(float64, float64, float64, float64, interface{}) bool); ok {
+			return curl.SetProgressFunction(fun)
+		}
+
+	case opt == OPT_XFERINFOFUNCTION:
+		if fun, ok := param.(func(int64, int64, int64, int64, interface{}) error); ok {
+			return curl.SetXferinfoFunction(fun)
 		}
 
 	case opt == OPT_HEADERFUNCTION:
-		fun := param.(func([]byte, interface{}) bool)
-		curl.headerFunction = &fun
-
-		ptr := C.return_header_function()
-		if err := newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt), ptr)); err == nil {
-			return newCurlError(C.curl_easy_setopt_pointer(p, OPT_HEADERDATA, unsafe.Pointer(curl.handle)))
-		} else {
-			return err
+		if fun, ok := param.(func([]byte, interface{}) bool); ok {
+			return curl.SetHeaderFunction(fun)
 		}
 
 	case opt == OPT_WRITEFUNCTION:
-		fun := param.(func([]byte, interface{}) bool)
-		curl.writeFunction = &fun
-
-		ptr := C.return_write_function()
-		if err := newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt), ptr)); err == nil {
-			return newCurlError(C.curl_easy_setopt_pointer(p, OPT_WRITEDATA, unsafe.Pointer(curl.handle)))
-		} else {
-			return err
+		if fun, ok := param.(func([]byte, interface{}) bool); ok {
+			return curl.SetWriteFunction(fun)
+		}
+
+	case opt == OPT_DEBUGFUNCTION:
+		if fun, ok := param.(func(DebugType, []byte, interface{})); ok {
+			return curl.SetDebugFunction(fun)
 		}
 
 	// for OPT_HTTPPOST, use struct Form
 	case opt == OPT_HTTPPOST:
-		post := param.(*Form)
-		ptr := post.head
-		return newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt), unsafe.Pointer(ptr)))
+		if form, ok := param.(*Form); ok {
+			return curl.SetHTTPPost(form)
+		}
 
-	case opt >= C.CURLOPTTYPE_OFF_T:
-		val := C.off_t(0)
+	case opt >= C.CURLOPTTYPE_BLOB:
 		switch t := param.(type) {
-		case int:
-			val = C.off_t(t)
-		case uint64:
-			val = C.off_t(t)
-		default:
-			panic("OFF_T conversion not supported")
+		case []byte:
+			return curl.setoptBlob(opt, t)
+		case CurlBlob:
+			return curl.setoptPointer(opt, unsafe.Pointer(t))
+		}
+
+	case opt >= C.CURLOPTTYPE_OFF_T:
+		if val, ok := integerValue(param); ok {
+			return curl.setoptOffT(opt, val)
 		}
-		return newCurlError(C.curl_easy_setopt_off_t(p, C.CURLoption(opt), val))
 
 	case opt >= C.CURLOPTTYPE_FUNCTIONPOINT:
-		// function pointer
-		panic("function pointer not implemented yet!")
+		// only the callbacks above are supported
 
 	case opt >= C.CURLOPTTYPE_OBJECTPOINT:
 		switch t := param.(type) {
 		case string:
-			ptr := C.CString(t)
-			curl.mallocAddPtr(ptr)
-			return newCurlError(C.curl_easy_setopt_string(p, C.CURLoption(opt), ptr))
+			return curl.setoptString(opt, t)
 		case CurlString:
-			ptr := (*C.char)(t)
-			return newCurlError(C.curl_easy_setopt_string(p, C.CURLoption(opt), ptr))
+			return newCurlError(C.curl_easy_setopt_string(p, C.CURLoption(opt), (*C.char)(t)))
 		case []string:
-			if len(t) > 0 {
-				ptr := C.CString(t[0])
-				curl.mallocAddPtr(ptr)
-				a_slist := C.curl_slist_append(nil, ptr)
-				for _, s := range t[1:] {
-					ptr := C.CString(s)
-					curl.mallocAddPtr(ptr)
-					a_slist = C.curl_slist_append(a_slist, ptr)
-				}
-				return newCurlError(C.curl_easy_setopt_slist(p, C.CURLoption(opt), a_slist))
-			} else {
-				return newCurlError(C.curl_easy_setopt_slist(p, C.CURLoption(opt), nil))
-			}
+			return curl.setoptSlist(opt, t)
 		case []CurlString:
-			if len(t) > 0 {
-				ptr := (*C.char)(t[0])
-				a_slist := C.curl_slist_append(nil, ptr)
-				for _, s := range t[1:] {
-					ptr := (*C.char)(s)
-					a_slist = C.curl_slist_append(a_slist, ptr)
-				}
-				return newCurlError(C.curl_easy_setopt_slist(p, C.CURLoption(opt), a_slist))
-			} else {
+			if len(t) == 0 {
 				return newCurlError(C.curl_easy_setopt_slist(p, C.CURLoption(opt), nil))
 			}
-		default:
-			// It panics if v's Kind is not Chan, Func, Map, Ptr, Slice, or UnsafePointer.
-			// val := reflect.ValueOf(param)
-			//fmt.Printf("DEBUG(Setopt): param=%x\n", val.Pointer())
-			//println("DEBUG can addr =", val.Pointer(), "opt=", opt)
-			// pass a pointer to GoInterface
-			return newCurlError(C.curl_easy_setopt_pointer(p, C.CURLoption(opt),
-				unsafe.Pointer(&param)))
+			a_slist := C.curl_slist_append(nil, (*C.char)(t[0]))
+			for _, s := range t[1:] {
+				a_slist = C.curl_slist_append(a_slist, (*C.char)(s))
+			}
+			return newCurlError(C.curl_easy_setopt_slist(p, C.CURLoption(opt), a_slist))
 		}
+
 	case opt >= C.CURLOPTTYPE_LONG:
-		val := C.long(0)
-		switch t := param.(type) {
-		case int:
-			val = C.long(t)
-		case bool:
-			if t {
-				val = 1
-			}
-		case int64:
-			val = C.long(t)
-		case int32:
-			val = C.long(t)
-		default:
-			panic("not supported converstion to c long")
+		if val, ok := integerValue(param); ok {
+			return curl.setoptLong(opt, val)
 		}
-		return newCurlError(C.curl_easy_setopt_long(p, C.CURLoption(opt), val))
 	}
-	panic("opt param error!")
+	return &OptionError{Option: opt, Value: param}
+}
+
+func (curl *CURL) setoptLong(opt int, value int64) error {
+	val := C.long(value)
+	// long is 32 bits on some platforms
+	if int64(val) != value {
+		return &OptionError{Option: opt, Value: value}
+	}
+	return newCurlError(C.curl_easy_setopt_long(curl.handle, C.CURLoption(opt), val))
+}
+
+func (curl *CURL) setoptOffT(opt int, value int64) error {
+	return newCurlError(C.curl_easy_setopt_off_t(curl.handle, C.CURLoption(opt), C.off_t(value)))
+}
+
+func (curl *CURL) setoptString(opt int, value string) error {
+	ptr := C.CString(value)
+	curl.mallocAddPtr(ptr)
+	return newCurlError(C.curl_easy_setopt_string(curl.handle, C.CURLoption(opt), ptr))
+}
+
+// setoptSlist sets a list, nil or empty clears it
+func (curl *CURL) setoptSlist(opt int, values []string) error {
+	var a_slist *C.struct_curl_slist
+	for _, s := range values {
+		ptr := C.CString(s)
+		curl.mallocAddPtr(ptr)
+		a_slist = C.curl_slist_append(a_slist, ptr)
+	}
+	return newCurlError(C.curl_easy_setopt_slist(curl.handle, C.CURLoption(opt), a_slist))
+}
+
+// setoptBlob sets a blob libcurl copies, so the Go memory is not kept
+func (curl *CURL) setoptBlob(opt int, value []byte) error {
+	if len(value) == 0 {
+		return newCurlError(C.curl_easy_setopt_pointer(curl.handle, C.CURLoption(opt), nil))
+	}
+	return newCurlError(C.curl_easy_setopt_blob(curl.handle, C.CURLoption(opt), unsafe.Pointer(&value[0]), C.size_t(len(value))))
+}
+
+func (curl *CURL) setoptPointer(opt int, ptr unsafe.Pointer) error {
+	return newCurlError(C.curl_easy_setopt_pointer(curl.handle, C.CURLoption(opt), ptr))
+}
+
+// setoptCallback sets the C trampoline of a callback, the handle is its
+// data so the trampoline finds the Go function
+func (curl *CURL) setoptCallback(opt, dataOpt int, trampoline unsafe.Pointer) error {
+	if err := curl.setoptPointer(opt, trampoline); err != nil {
+		return err
+	}
+	return curl.setoptPointer(dataOpt, curl.handle)
 }
 
 // curl_easy_send - sends raw data over an "easy" connection
 func (curl *CURL) Send(buffer []byte) (int, error) {
 	p := curl.handle
-	buflen := len(buffer)
+	if len(buffer) == 0 {
+		return 0, nil
+	}
 	n := C.size_t(0)
-	ret := C.curl_easy_send(p, unsafe.Pointer(&buffer[0]), C.size_t(buflen), &n)
+	ret := C.curl_easy_send(p, unsafe.Pointer(&buffer[0]), C.size_t(len(buffer)), &n)
 	return int(n), newCurlError(ret)
 }
 
 // curl_easy_recv - receives raw data on an "easy" connection
 func (curl *CURL) Recv(buffer []byte) (int, error) {
 	p := curl.handle
-	buflen := len(buffer)
-	buf := C.CString(string(buffer))
+	if len(buffer) == 0 {
+		return 0, nil
+	}
 	n := C.size_t(0)
-	ret := C.curl_easy_recv(p, unsafe.Pointer(buf), C.size_t(buflen), &n)
-	return copy(buffer, C.GoStringN(buf, C.int(n))), newCurlError(ret)
+	ret := C.curl_easy_recv(p, unsafe.Pointer(&buffer[0]), C.size_t(len(buffer)), &n)
+	return int(n), newCurlError(ret)
+}
+
+// WaitSocket waits up to timeout_ms, -1 for ever, until socket is ready for
+// Send or Recv, socket is the INFO_ACTIVESOCKET of a OPT_CONNECT_ONLY
+// transfer. It returns false on timeout, errors and hang ups of the socket
+// count as ready and are reported by the next Send or Recv. Send and Recv
+// return E_AGAIN while the socket is not ready.
+func WaitSocket(socket int, forSend bool, timeout_ms int) (bool, error) {
+	sendFlag := C.int(0)
+	if forSend {
+		sendFlag = 1
+	}
+	ret, err := C.curl_wait_socket(C.curl_socket_t(socket), sendFlag, C.int(timeout_ms))
+	if ret < 0 {
+		return false, err
+	}
+	return ret > 0, nil
 }
 
 // curl_easy_perform - Perform a file transfer
+// the error of a transfer aborted by the xferinfo function is the error it returned
 func (curl *CURL) Perform() error {
 	p := curl.handle
-	return newCurlError(C.curl_easy_perform(p))
+	curl.xferinfoErr = nil
+	return curl.XferinfoError(newCurlError(C.curl_easy_perform(p)))
+}
+
+// XferinfoError returns the error the xferinfo function aborted the transfer
+// with when result, the result of the transfer, is E_ABORTED_BY_CALLBACK.
+// Otherwise result is returned, like for the results of a multi handle.
+func (curl *CURL) XferinfoError(result error) error {
+	if result == CurlError(E_ABORTED_BY_CALLBACK) && curl.xferinfoErr != nil {
+		return curl.xferinfoErr
+	}
+	return result
 }
 
 // curl_easy_pause - pause and unpause a connection
@@ -382,43 +512,124 @@ func (curl *CURL) Unescape(url string) string {
 
 // curl_easy_getinfo - extract information from a curl handle
 func (curl *CURL) Getinfo(info CurlInfo) (ret interface{}, err error) {
-	p := curl.handle
 	cInfo := C.CURLINFO(info)
+	switch {
+	case cInfo == C.CURLINFO_PRIVATE:
+		// a pointer, though its type is CURLINFO_STRING
+		return curl.getinfoPointer(info)
+	case cInfo == C.CURLINFO_CERTINFO:
+		return curl.getinfoCertinfo()
+	case cInfo == C.CURLINFO_TLS_SESSION || cInfo == C.CURLINFO_TLS_SSL_PTR:
+		return curl.getinfoTLSSession(info)
+	}
+
 	switch cInfo & C.CURLINFO_TYPEMASK {
 	case C.CURLINFO_STRING:
-		a_string := C.CString("")
-		defer C.free(unsafe.Pointer(a_string))
-		err := newCurlError(C.curl_easy_getinfo_string(p, cInfo, &a_string))
-		ret := C.GoString(a_string)
-		debugf("Getinfo %s", ret)
-		return ret, err
+		return curl.getinfoString(info)
 	case C.CURLINFO_LONG:
-		a_long := C.long(-1)
-		err := newCurlError(C.curl_easy_getinfo_long(p, cInfo, &a_long))
-		ret := int(a_long)
-		debugf("Getinfo %s", ret)
-		return ret, err
+		return curl.getinfoLong(info)
 	case C.CURLINFO_DOUBLE:
-		a_double := C.double(0.0)
-		err := newCurlError(C.curl_easy_getinfo_double(p, cInfo, &a_double))
-		ret := float64(a_double)
-		debugf("Getinfo %s", ret)
-		return ret, err
+		return curl.getinfoDouble(info)
+	case C.CURLINFO_SOCKET:
+		return curl.getinfoSocket(info)
+	case C.CURLINFO_OFF_T:
+		return curl.getinfoOffT(info)
 	case C.CURLINFO_SLIST:
-		a_ptr_slist := new(C.struct_curl_slist)
-		err := newCurlError(C.curl_easy_getinfo_slist(p, cInfo, &a_ptr_slist))
-		ret := []string{}
-		for a_ptr_slist != nil {
-			debugf("Getinfo %s %v", C.GoString(a_ptr_slist.data), a_ptr_slist.next)
-			ret = append(ret, C.GoString(a_ptr_slist.data))
-			a_ptr_slist = a_ptr_slist.next
+		return curl.getinfoSlist(info)
+	}
+	return nil, CurlError(E_UNKNOWN_OPTION)
+}
+
+func (curl *CURL) getinfoString(info CurlInfo) (string, error) {
+	var a_string *C.char
+	err := newCurlError(C.curl_easy_getinfo_string(curl.handle, C.CURLINFO(info), &a_string))
+	ret := C.GoString(a_string)
+	debugf("Getinfo %s", ret)
+	return ret, err
+}
+
+func (curl *CURL) getinfoLong(info CurlInfo) (int, error) {
+	a_long := C.long(-1)
+	err := newCurlError(C.curl_easy_getinfo_long(curl.handle, C.CURLINFO(info), &a_long))
+	ret := int(a_long)
+	debugf("Getinfo %v", ret)
+	return ret, err
+}
+
+func (curl *CURL) getinfoDouble(info CurlInfo) (float64, error) {
+	a_double := C.double(0.0)
+	err := newCurlError(C.curl_easy_getinfo_double(curl.handle, C.CURLINFO(info), &a_double))
+	ret := float64(a_double)
+	debugf("Getinfo %v", ret)
+	return ret, err
+}
+
+func (curl *CURL) getinfoSocket(info CurlInfo) (int, error) {
+	a_socket := C.curl_socket_t(C.CURL_SOCKET_BAD)
+	err := newCurlError(C.curl_easy_getinfo_socket(curl.handle, C.CURLINFO(info), &a_socket))
+	ret := int(a_socket)
+	debugf("Getinfo %v", ret)
+	return ret, err
+}
+
+func (curl *CURL) getinfoOffT(info CurlInfo) (int64, error) {
+	a_off_t := C.curl_off_t(0)
+	err := newCurlError(C.curl_easy_getinfo_off_t(curl.handle, C.CURLINFO(info), &a_off_t))
+	ret := int64(a_off_t)
+	debugf("Getinfo %v", ret)
+	return ret, err
+}
+
+func (curl *CURL) getinfoSlist(info CurlInfo) ([]string, error) {
+	cInfo := C.CURLINFO(info)
+	var a_ptr_slist *C.struct_curl_slist
+	err := newCurlError(C.curl_easy_getinfo_slist(curl.handle, cInfo, &a_ptr_slist))
+	head := a_ptr_slist
+	ret := []string{}
+	for a_ptr_slist != nil {
+		debugf("Getinfo %s %v", C.GoString(a_ptr_slist.data), a_ptr_slist.next)
+		ret = append(ret, C.GoString(a_ptr_slist.data))
+		a_ptr_slist = a_ptr_slist.next
+	}
+	// these lists are allocated for the caller
+	if err == nil && (cInfo == C.CURLINFO_COOKIELIST || cInfo == C.CURLINFO_SSL_ENGINES) {
+		C.curl_slist_free_all(head)
+	}
+	return ret, err
+}
+
+// getinfoCertinfo reads a list of "name:value" entries for each certificate of the chain
+func (curl *CURL) getinfoCertinfo() ([][]string, error) {
+	var a_certinfo *C.struct_curl_certinfo
+	err := newCurlError(C.curl_easy_getinfo_certinfo(curl.handle, &a_certinfo))
+	ret := [][]string{}
+	if a_certinfo != nil {
+		for i := C.int(0); i < a_certinfo.num_of_certs; i++ {
+			cert := []string{}
+			for a_ptr_slist := C.certinfo_index(a_certinfo, i); a_ptr_slist != nil; a_ptr_slist = a_ptr_slist.next {
+				cert = append(cert, C.GoString(a_ptr_slist.data))
+			}
+			ret = append(ret, cert)
 		}
-		return ret, err
-	default:
-		panic("error calling Getinfo\n")
 	}
-	panic("not implemented yet!")
-	return nil, nil
+	return ret, err
+}
+
+func (curl *CURL) getinfoPointer(info CurlInfo) (unsafe.Pointer, error) {
+	var a_ptr unsafe.Pointer
+	err := newCurlError(C.curl_easy_getinfo_pointer(curl.handle, C.CURLINFO(info), &a_ptr))
+	return a_ptr, err
+}
+
+// getinfoTLSSession reads INFO_TLS_SSL_PTR or the deprecated INFO_TLS_SESSION
+func (curl *CURL) getinfoTLSSession(info CurlInfo) (*TLSSessionInfo, error) {
+	var a_session *C.struct_curl_tlssessioninfo
+	err := newCurlError(C.curl_easy_getinfo_tlssession(curl.handle, C.CURLINFO(info), &a_session))
+	// libcurl has no internals without a TLS connection
+	if err != nil || a_session == nil || a_session.internals == nil {
+		return nil, err
+	}
+	return &TLSSessionInfo{Backend: int(a_session.backend), Internals: a_session.internals}, nil
 }
 
 func (curl *CURL) GetHandle() unsafe.Pointer {
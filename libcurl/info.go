@@ -0,0 +1,128 @@
+package libcurl
+
+/*
+#include "./include/curl.h"
+
+struct transfer_info {
+  curl_off_t namelookup, connect, appconnect, pretransfer, starttransfer, redirect, total;
+  curl_off_t size_upload, size_download, speed_upload, speed_download;
+  long redirect_count, num_connects, header_size, request_size;
+  long primary_port, local_port, response_code, http_version;
+  char *primary_ip, *local_ip, *effective_url;
+};
+
+static CURLcode curl_easy_transfer_info(CURL *curl, struct transfer_info *info) {
+  CURLcode ret;
+#define GETINFO(id, field) \
+  if((ret = curl_easy_getinfo(curl, id, &info->field)) != CURLE_OK) \
+    return ret;
+  GETINFO(CURLINFO_NAMELOOKUP_TIME_T, namelookup)
+  GETINFO(CURLINFO_CONNECT_TIME_T, connect)
+  GETINFO(CURLINFO_APPCONNECT_TIME_T, appconnect)
+  GETINFO(CURLINFO_PRETRANSFER_TIME_T, pretransfer)
+  GETINFO(CURLINFO_STARTTRANSFER_TIME_T, starttransfer)
+  GETINFO(CURLINFO_REDIRECT_TIME_T, redirect)
+  GETINFO(CURLINFO_TOTAL_TIME_T, total)
+  GETINFO(CURLINFO_SIZE_UPLOAD_T, size_upload)
+  GETINFO(CURLINFO_SIZE_DOWNLOAD_T, size_download)
+  GETINFO(CURLINFO_SPEED_UPLOAD_T, speed_upload)
+  GETINFO(CURLINFO_SPEED_DOWNLOAD_T, speed_download)
+  GETINFO(CURLINFO_REDIRECT_COUNT, redirect_count)
+  GETINFO(CURLINFO_NUM_CONNECTS, num_connects)
+  GETINFO(CURLINFO_HEADER_SIZE, header_size)
+  GETINFO(CURLINFO_REQUEST_SIZE, request_size)
+  GETINFO(CURLINFO_PRIMARY_PORT, primary_port)
+  GETINFO(CURLINFO_LOCAL_PORT, local_port)
+  GETINFO(CURLINFO_RESPONSE_CODE, response_code)
+  GETINFO(CURLINFO_HTTP_VERSION, http_version)
+  GETINFO(CURLINFO_PRIMARY_IP, primary_ip)
+  GETINFO(CURLINFO_LOCAL_IP, local_ip)
+  GETINFO(CURLINFO_EFFECTIVE_URL, effective_url)
+#undef GETINFO
+  return CURLE_OK;
+}
+*/
+import "C"
+
+import "time"
+
+// TransferInfo is the timing and size breakdown of a transfer. The times
+// are measured from the start of the transfer, like the INFO_*_TIME values.
+type TransferInfo struct {
+	NameLookupTime    time.Duration
+	ConnectTime       time.Duration
+	AppConnectTime    time.Duration // TLS handshake done, 0 without TLS
+	PreTransferTime   time.Duration
+	StartTransferTime time.Duration // first response byte
+	RedirectTime      time.Duration
+	TotalTime         time.Duration
+
+	SizeUpload    int64
+	SizeDownload  int64
+	SpeedUpload   int64 // bytes per second
+	SpeedDownload int64 // bytes per second
+	HeaderSize    int
+	RequestSize   int
+
+	RedirectCount int
+	NumConnects   int // new connections, 0 when one was reused
+	ResponseCode  int
+	HTTPVersion   int // one of the HTTP_VERSION_* constants
+	EffectiveURL  string
+
+	PrimaryIP   string
+	PrimaryPort int
+	LocalIP     string
+	LocalPort   int
+}
+
+// TransferInfo reads all values of TransferInfo in one call
+func (curl *CURL) TransferInfo() (*TransferInfo, error) {
+	var info C.struct_transfer_info
+	if err := newCurlError(C.curl_easy_transfer_info(curl.handle, &info)); err != nil {
+		return nil, err
+	}
+
+	micro := func(v C.curl_off_t) time.Duration {
+		return time.Duration(v) * time.Microsecond
+	}
+	return &TransferInfo{
+		NameLookupTime:    micro(info.namelookup),
+		ConnectTime:       micro(info.connect),
+		AppConnectTime:    micro(info.appconnect),
+		PreTransferTime:   micro(info.pretransfer),
+		StartTransferTime: micro(info.starttransfer),
+		RedirectTime:      micro(info.redirect),
+		TotalTime:         micro(info.total),
+
+		SizeUpload:    int64(info.size_upload),
+		SizeDownload:  int64(info.size_download),
+		SpeedUpload:   int64(info.speed_upload),
+		SpeedDownload: int64(info.speed_download),
+		HeaderSize:    int(info.header_size),
+		RequestSize:   int(info.request_size),
+
+		RedirectCount: int(info.redirect_count),
+		NumConnects:   int(info.num_connects),
+		ResponseCode:  int(info.response_code),
+		HTTPVersion:   int(info.http_version),
+		EffectiveURL:  C.GoString(info.effective_url),
+
+		PrimaryIP:   C.GoString(info.primary_ip),
+		PrimaryPort: int(info.primary_port),
+		LocalIP:     C.GoString(info.local_ip),
+		LocalPort:   int(info.local_port),
+	}, nil
+}
+
+// getinfoMicroseconds reads an off_t time in microseconds, like INFO_TOTAL_TIME_T
+func (curl *CURL) getinfoMicroseconds(info CurlInfo) (time.Duration, error) {
+	us, err := curl.getinfoOffT(info)
+	return time.Duration(us) * time.Microsecond, err
+}
+
+// getinfoSeconds reads an off_t time in seconds, like INFO_RETRY_AFTER
+func (curl *CURL) getinfoSeconds(info CurlInfo) (time.Duration, error) {
+	s, err := curl.getinfoOffT(info)
+	return time.Duration(s) * time.Second, err
+}
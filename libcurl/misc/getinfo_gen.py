@@ -0,0 +1,89 @@
+#!/usr/bin/env python3
+# generates getinfo_gen.go, the typed info accessors of CURL, from the
+# CURLINFO table of include/curl.h. Run it from the libcurl directory.
+
+import re
+import subprocess
+
+from setopt_gen import WORDS, go_name
+
+WORDS.update({
+    'VERIFYRESULT': 'VerifyResult', 'CONNECTCODE': 'ConnectCode', 'LASTSOCKET': 'LastSocket',
+    'ACTIVESOCKET': 'ActiveSocket', 'ERRNO': 'Errno', 'OS': 'OS', 'IP': 'IP',
+    'NAMELOOKUP': 'NameLookup', 'PRETRANSFER': 'PreTransfer', 'STARTTRANSFER': 'StartTransfer',
+    'APPCONNECT': 'AppConnect',
+})
+
+# infos read by hand written code, Getinfo reads them too
+SKIP = {'PRIVATE', 'TLS_SESSION', 'TLS_SSL_PTR', 'LASTSOCKET'}
+
+
+def parse_infos(header):
+    return re.findall(r'CURLINFO_(\w+)\s*=\s*CURLINFO_(STRING|LONG|DOUBLE|SLIST|PTR|SOCKET|OFF_T)\s*\+\s*\d+', header)
+
+
+def accessor(info, kind, names):
+    const = 'INFO_' + info
+    if info in SKIP:
+        return None
+    if kind in ('DOUBLE', 'LONG') and info + '_T' in names:
+        # the off_t version is exact and does not overflow a 32-bit long
+        return None
+    if kind == 'OFF_T':
+        name = info[:-len('_T')] if info.endswith('_T') else info
+        if name.endswith('_TIME'):
+            # microseconds
+            return go_name(name), 'time.Duration', 'curl.getinfoMicroseconds(%s)' % const
+        if name == 'RETRY_AFTER':
+            return go_name(name), 'time.Duration', 'curl.getinfoSeconds(%s)' % const
+        return go_name(name), 'int64', 'curl.getinfoOffT(%s)' % const
+    if kind == 'STRING':
+        return go_name(info), 'string', 'curl.getinfoString(%s)' % const
+    if kind == 'LONG':
+        return go_name(info), 'int', 'curl.getinfoLong(%s)' % const
+    if kind == 'DOUBLE':
+        return go_name(info), 'float64', 'curl.getinfoDouble(%s)' % const
+    if kind == 'SOCKET':
+        return go_name(info), 'int', 'curl.getinfoSocket(%s)' % const
+    if info == 'CERTINFO':
+        return go_name(info), '[][]string', 'curl.getinfoCertinfo()'
+    if kind == 'SLIST':
+        return go_name(info), '[]string', 'curl.getinfoSlist(%s)' % const
+    return None
+
+
+def main():
+    with open('include/curl.h') as f:
+        infos = parse_infos(f.read())
+    with open('const_gen.go') as f:
+        known = set(re.findall(r'^\s+INFO_(\w+)\s+=', f.read(), re.M))
+
+    names = {info for info, _ in infos}
+    out = [
+        '// Code generated by misc/getinfo_gen.py from include/curl.h. DO NOT EDIT.',
+        '',
+        'package libcurl',
+        '',
+        'import "time"',
+        '',
+    ]
+    for info, kind in infos:
+        if info not in known:
+            continue
+        generated = accessor(info, kind, names)
+        if generated is None:
+            continue
+        name, result, body = generated
+        out.append('// %s gets INFO_%s' % (name, info))
+        out.append('func (curl *CURL) %s() (%s, error) {' % (name, result))
+        out.append('\treturn ' + body)
+        out.append('}')
+        out.append('')
+
+    with open('getinfo_gen.go', 'w') as f:
+        f.write('\n'.join(out))
+    subprocess.call(['gofmt', '-w', 'getinfo_gen.go'])
+
+
+if __name__ == '__main__':
+    main()
@@ -0,0 +1,163 @@
+#!/usr/bin/env python3
+# generates setopt_gen.go, the typed option setters of CURL, from the
+# CURLOPT table of include/curl.h. Run it from the libcurl directory.
+
+import re
+import subprocess
+
+# options taking a long which is 0 or 1
+BOOLEANS = {
+    'VERBOSE', 'HEADER', 'NOPROGRESS', 'NOBODY', 'FAILONERROR', 'UPLOAD', 'POST',
+    'DIRLISTONLY', 'APPEND', 'FOLLOWLOCATION', 'TRANSFERTEXT', 'PUT', 'AUTOREFERER',
+    'HTTPPROXYTUNNEL', 'SSL_VERIFYPEER', 'FILETIME', 'FRESH_CONNECT', 'FORBID_REUSE',
+    'HTTPGET', 'FTP_USE_EPSV', 'SSLENGINE_DEFAULT', 'DNS_USE_GLOBAL_CACHE', 'COOKIESESSION',
+    'NOSIGNAL', 'UNRESTRICTED_AUTH', 'FTP_USE_EPRT', 'IGNORE_CONTENT_LENGTH', 'FTP_SKIP_PASV_IP',
+    'TCP_NODELAY', 'SSL_SESSIONID_CACHE', 'HTTP_TRANSFER_DECODING', 'HTTP_CONTENT_DECODING',
+    'PROXY_TRANSFER_MODE', 'CERTINFO', 'SOCKS5_GSSAPI_NEC', 'FTP_USE_PRET', 'WILDCARDMATCH',
+    'TRANSFER_ENCODING', 'TCP_KEEPALIVE', 'SASL_IR', 'SSL_ENABLE_NPN', 'SSL_ENABLE_ALPN',
+    'SSL_VERIFYSTATUS', 'SSL_FALSESTART', 'PATH_AS_IS', 'PIPEWAIT', 'TFTP_NO_OPTIONS',
+    'TCP_FASTOPEN', 'KEEP_SENDING_ON_ERROR', 'PROXY_SSL_VERIFYPEER', 'SUPPRESS_CONNECT_HEADERS',
+    'SSH_COMPRESSION', 'HAPROXYPROTOCOL', 'DNS_SHUFFLE_ADDRESSES', 'DISALLOW_USERNAME_IN_URL',
+    'HTTP09_ALLOWED', 'MAIL_RCPT_ALLLOWFAILS', 'CRLF',
+}
+
+# options taking a long which is a duration, in milliseconds or seconds
+MILLISECONDS = {
+    'TIMEOUT_MS', 'CONNECTTIMEOUT_MS', 'ACCEPTTIMEOUT_MS', 'EXPECT_100_TIMEOUT_MS',
+    'HAPPY_EYEBALLS_TIMEOUT_MS', 'UPKEEP_INTERVAL_MS',
+}
+SECONDS = {
+    'FTP_RESPONSE_TIMEOUT', 'LOW_SPEED_TIME', 'DNS_CACHE_TIMEOUT', 'TCP_KEEPIDLE',
+    'TCP_KEEPINTVL', 'MAXAGE_CONN',
+}
+
+# object pointers taking a string
+STRING_OBJECTS = {'POSTFIELDS', 'COPYPOSTFIELDS'}
+
+# upper case words and the words run together in the option names
+INITIALISMS = {
+    'URL', 'HTTP', 'HTTP09', 'SSL', 'TLS', 'TLS13', 'FTP', 'DNS', 'TCP', 'IP4', 'IP6', 'SSH',
+    'MD5', 'RTSP', 'ID', 'URI', 'CRLF', 'EPSV', 'EPRT', 'PRET', 'CCC', 'TFTP', 'GSSAPI', 'NEC',
+    'SOCKS5', 'SASL', 'IR', 'NPN', 'ALPN', 'XOAUTH2', 'CA', 'KRB', 'EGD', 'CRL', 'IP', 'PASV',
+}
+WORDS = {
+    'HTTPHEADER': 'HTTPHeader', 'USERPWD': 'UserPwd', 'PROXYUSERPWD': 'ProxyUserPwd',
+    'INFILESIZE': 'InfileSize', 'POSTFIELDS': 'PostFields', 'FTPPORT': 'FTPPort',
+    'USERAGENT': 'UserAgent', 'SSLCERT': 'SSLCert', 'KEYPASSWD': 'KeyPasswd',
+    'POSTQUOTE': 'PostQuote', 'COOKIEFILE': 'CookieFile', 'SSLVERSION': 'SSLVersion',
+    'TIMECONDITION': 'TimeCondition', 'TIMEVALUE': 'TimeValue', 'CUSTOMREQUEST': 'CustomRequest',
+    'NOPROGRESS': 'NoProgress', 'NOBODY': 'NoBody', 'FAILONERROR': 'FailOnError',
+    'DIRLISTONLY': 'DirListOnly', 'FOLLOWLOCATION': 'FollowLocation', 'TRANSFERTEXT': 'TransferText',
+    'AUTOREFERER': 'AutoReferer', 'PROXYPORT': 'ProxyPort', 'POSTFIELDSIZE': 'PostFieldSize',
+    'HTTPPROXYTUNNEL': 'HTTPProxyTunnel', 'KRBLEVEL': 'KRBLevel', 'CAINFO': 'CAInfo',
+    'MAXREDIRS': 'MaxRedirs', 'FILETIME': 'FileTime', 'TELNETOPTIONS': 'TelnetOptions',
+    'MAXCONNECTS': 'MaxConnects', 'EGDSOCKET': 'EGDSocket', 'CONNECTTIMEOUT': 'ConnectTimeout',
+    'HTTPGET': 'HTTPGet', 'COOKIEJAR': 'CookieJar', 'SSLCERTTYPE': 'SSLCertType',
+    'SSLKEY': 'SSLKey', 'SSLKEYTYPE': 'SSLKeyType', 'SSLENGINE': 'SSLEngine', 'PREQUOTE': 'PreQuote',
+    'COOKIESESSION': 'CookieSession', 'CAPATH': 'CAPath', 'BUFFERSIZE': 'BufferSize',
+    'NOSIGNAL': 'NoSignal', 'PROXYTYPE': 'ProxyType', 'HTTP200ALIASES': 'HTTP200Aliases',
+    'HTTPAUTH': 'HTTPAuth', 'PROXYAUTH': 'ProxyAuth', 'IPRESOLVE': 'IPResolve',
+    'MAXFILESIZE': 'MaxFileSize', 'FTPSSLAUTH': 'FTPSSLAuth', 'COOKIELIST': 'CookieList',
+    'LOCALPORT': 'LocalPort', 'LOCALPORTRANGE': 'LocalPortRange', 'KEYFILE': 'KeyFile',
+    'COPYPOSTFIELDS': 'CopyPostFields', 'POSTREDIR': 'PostRedir', 'CRLFILE': 'CRLFile',
+    'ISSUERCERT': 'IssuerCert', 'CERTINFO': 'CertInfo', 'USERNAME': 'Username',
+    'PROXYUSERNAME': 'ProxyUsername', 'PROXYPASSWORD': 'ProxyPassword', 'NOPROXY': 'NoProxy',
+    'BLKSIZE': 'BlkSize', 'KNOWNHOSTS': 'KnownHosts', 'WILDCARDMATCH': 'WildcardMatch',
+    'ACCEPTTIMEOUT': 'AcceptTimeout', 'KEEPALIVE': 'KeepAlive', 'KEEPIDLE': 'KeepIdle',
+    'KEEPINTVL': 'KeepIntvl', 'PROXYHEADER': 'ProxyHeader', 'HEADEROPT': 'HeaderOpt',
+    'PINNEDPUBLICKEY': 'PinnedPublicKey', 'VERIFYPEER': 'VerifyPeer', 'VERIFYHOST': 'VerifyHost',
+    'VERIFYSTATUS': 'VerifyStatus', 'FALSESTART': 'FalseStart', 'PIPEWAIT': 'PipeWait',
+    'FASTOPEN': 'FastOpen', 'HAPROXYPROTOCOL': 'HAProxyProtocol', 'ALTSVC': 'AltSvc',
+    'AUTHZID': 'AuthzID', 'ALLLOWFAILS': 'AllowFails', 'NODELAY': 'NoDelay',
+    'FILEMETHOD': 'FileMethod', 'CSEQ': 'CSeq', 'MAXAGE': 'MaxAge', 'TLSAUTH': 'TLSAuth',
+    'SESSIONID': 'SessionID', 'SSLAUTH': 'SSLAuth', 'NETRC': 'Netrc', 'CIPHER': 'Cipher',
+    'PROXYTUNNEL': 'ProxyTunnel', 'DOH': 'DoH',
+}
+
+
+def go_name(option):
+    words = []
+    for part in option.split('_'):
+        if part in WORDS:
+            words.append(WORDS[part])
+        elif part in INITIALISMS:
+            words.append(part)
+        else:
+            words.append(part.capitalize())
+    return ''.join(words)
+
+
+def parse_options(header):
+    return re.findall(r'CURLOPT\(CURLOPT_(\w+),\s*CURLOPTTYPE_(\w+),\s*\d+\)', header)
+
+
+def setter(option, kind, names):
+    const = 'OPT_' + option
+    if kind == 'OFF_T':
+        # the long version of a _LARGE option has no setter of its own
+        name = go_name(option[:-len('_LARGE')] if option.endswith('_LARGE') else option)
+        return name, 'value int64', 'curl.setoptOffT(%s, value)' % const
+    if kind == 'LONG':
+        if option + '_LARGE' in names or option + '_MS' in names:
+            return None
+        if option in BOOLEANS:
+            return go_name(option), 'enable bool', 'curl.setoptBool(%s, enable)' % const
+        if option in MILLISECONDS:
+            return go_name(option[:-len('_MS')]), 'value time.Duration', \
+                'curl.setoptLong(%s, durationMilliseconds(value))' % const
+        if option in SECONDS:
+            return go_name(option), 'value time.Duration', \
+                'curl.setoptLong(%s, durationSeconds(value))' % const
+        return go_name(option), 'value int', 'curl.setoptLong(%s, int64(value))' % const
+    if kind == 'STRINGPOINT' or (kind == 'OBJECTPOINT' and option in STRING_OBJECTS):
+        return go_name(option), 'value string', 'curl.setoptString(%s, value)' % const
+    if kind == 'SLISTPOINT':
+        return go_name(option), 'values []string', 'curl.setoptSlist(%s, values)' % const
+    if kind == 'BLOB':
+        return go_name(option), 'value []byte', 'curl.setoptBlob(%s, value)' % const
+    # callbacks and other pointers are set by hand written setters
+    return None
+
+
+def main():
+    with open('include/curl.h') as f:
+        options = parse_options(f.read())
+    with open('const_gen.go') as f:
+        known = set(re.findall(r'^\s+OPT_(\w+)\s+=', f.read(), re.M))
+
+    names = {option for option, _ in options}
+    out = [
+        '// Code generated by misc/setopt_gen.py from include/curl.h. DO NOT EDIT.',
+        '',
+        'package libcurl',
+        '',
+        'import "time"',
+        '',
+    ]
+    for option, kind in options:
+        if option not in known or option.startswith('OBSOLETE'):
+            continue
+        generated = setter(option, kind, names)
+        if generated is None:
+            continue
+        name, param, body = generated
+        out.append('// Set%s sets OPT_%s' % (name, option))
+        out.append('func (curl *CURL) Set%s(%s) error {' % (name, param))
+        out.append('\treturn ' + body)
+        out.append('}')
+        out.append('')
+
+    out.append('// the names of the options, for errors')
+    out.append('var optionNames = map[int]string{')
+    for option, _ in options:
+        if option in known:
+            out.append('\tOPT_%s: "OPT_%s",' % (option, option))
+    out.append('}')
+
+    with open('setopt_gen.go', 'w') as f:
+        f.write('\n'.join(out) + '\n')
+    subprocess.call(['gofmt', '-w', 'setopt_gen.go'])
+
+
+if __name__ == '__main__':
+    main()
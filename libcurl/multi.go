@@ -2,6 +2,7 @@
 // must skip it. https://github.com/andelf/go-curl/issues/48
 
 // +build !windows
+
 package libcurl
 
 /*
@@ -26,6 +27,10 @@ static CURLMsg *curl_multi_info_read_pointer(CURLM *handle, int *msgs_in_queue)
 {
   return curl_multi_info_read(handle, msgs_in_queue);
 }                            
+static CURLcode curl_msg_result(void *data)
+{
+  return *(CURLcode *)data;
+}
 */
 import "C"
 
@@ -73,6 +78,11 @@ type CURLMessage struct {
 	Data [unsafe.Sizeof(dummy)]byte
 }
 
+// Result returns the transfer result of a CURLMSG_DONE message
+func (msg *CURLMessage) Result() error {
+	return newCurlError(C.curl_msg_result(unsafe.Pointer(&msg.Data[0])))
+}
+
 // curl_multi_init - create a multi handle
 func MultiInit() *CURLM {
 	p := C.curl_multi_init()
@@ -114,6 +124,20 @@ func (mcurl *CURLM) Timeout() (int, error) {
 	return int(timeout), err
 }
 
+// curl_multi_poll - polls on all easy handles in a multi handle
+func (mcurl *CURLM) Poll(timeout_ms int) (int, error) {
+	p := mcurl.handle
+	numfds := C.int(0)
+	err := newCurlMultiError(C.curl_multi_poll(p, nil, 0, C.int(timeout_ms), &numfds))
+	return int(numfds), err
+}
+
+// curl_multi_wakeup - wakes up a sleeping curl_multi_poll call
+func (mcurl *CURLM) Wakeup() error {
+	p := mcurl.handle
+	return newCurlMultiError(C.curl_multi_wakeup(p))
+}
+
 func (mcurl *CURLM) Setopt(opt int, param interface{}) error {
 	p := mcurl.handle
 	if param == nil {
@@ -138,7 +162,6 @@ func (mcurl *CURLM) Setopt(opt int, param interface{}) error {
 		}
 	}
 	panic("not supported CURLM.Setopt opt or param")
-	return nil
 }
 
 func (mcurl *CURLM) Fdset(rset, wset, eset *syscall.FdSet) (int, error) {
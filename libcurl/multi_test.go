@@ -0,0 +1,65 @@
+package libcurl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMultiPerform(t *testing.T) {
+	ts := setupTestServer("")
+	defer ts.Close()
+
+	multi := MultiInit()
+	defer multi.Cleanup()
+
+	easy := EasyInit()
+	defer easy.Cleanup()
+
+	easy.Setopt(OPT_URL, ts.URL)
+	if err := multi.AddHandle(easy); err != nil {
+		t.Fatal(err)
+	}
+	defer multi.RemoveHandle(easy)
+
+	for {
+		running, err := multi.Perform()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if running == 0 {
+			break
+		}
+		if _, err := multi.Poll(1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msg, _ := multi.Info_read()
+	if msg == nil || msg.Msg != CURLMSG_DONE {
+		t.Fatalf("message should be CURLMSG_DONE and is %v.", msg)
+	}
+	if msg.Easy_handle.GetHandle() != easy.GetHandle() {
+		t.Error("message should be for the added easy handle.")
+	}
+	if err := msg.Result(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultiWakeup(t *testing.T) {
+	multi := MultiInit()
+	defer multi.Cleanup()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		multi.Wakeup()
+	}()
+
+	start := time.Now()
+	if _, err := multi.Poll(5000); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("poll should return once woken up and took %v.", elapsed)
+	}
+}
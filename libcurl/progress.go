@@ -0,0 +1,88 @@
+package libcurl
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress is the state of a transfer, the totals are 0 when unknown
+type Progress struct {
+	DownloadTotal int64
+	Downloaded    int64
+	UploadTotal   int64
+	Uploaded      int64
+}
+
+// ProgressStream reports the progress of the transfers of the handle on the
+// returned channel, at most once per interval. The channel only keeps the
+// latest progress, so a slow receiver never stalls the transfer. stop sends
+// the last progress and closes the channel, call it once the transfer is
+// over. It sets OPT_NOPROGRESS to 0 and replaces the xferinfo function.
+func (curl *CURL) ProgressStream(interval time.Duration) (progress <-chan Progress, stop func(), err error) {
+	stream := &progressStream{
+		interval: interval,
+		ch:       make(chan Progress, 1),
+	}
+
+	err = curl.setoptBool(OPT_NOPROGRESS, false)
+	if err != nil {
+		return
+	}
+
+	err = curl.SetXferinfoFunction(func(dltotal, dlnow, ultotal, ulnow int64, userdata interface{}) error {
+		stream.update(Progress{DownloadTotal: dltotal, Downloaded: dlnow, UploadTotal: ultotal, Uploaded: ulnow})
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	return stream.ch, stream.stop, nil
+}
+
+type progressStream struct {
+	interval time.Duration
+	ch       chan Progress
+
+	mu      sync.Mutex
+	last    Progress
+	sent    Progress
+	sentAt  time.Time
+	stopped bool
+}
+
+func (s *progressStream) update(p Progress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = p
+	if s.stopped || p == s.sent || time.Since(s.sentAt) < s.interval {
+		return
+	}
+	s.send(p)
+}
+
+// send replaces the progress the receiver did not take yet, it never blocks
+// as the stream is the only sender
+func (s *progressStream) send(p Progress) {
+	select {
+	case <-s.ch:
+	default:
+	}
+	s.ch <- p
+	s.sent = p
+	s.sentAt = time.Now()
+}
+
+func (s *progressStream) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	if s.last != s.sent {
+		s.send(s.last)
+	}
+	close(s.ch)
+}
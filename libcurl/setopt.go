@@ -0,0 +1,164 @@
+package libcurl
+
+//go:generate python3 ./misc/setopt_gen.py
+//go:generate python3 ./misc/getinfo_gen.py
+
+/*
+#include "callback.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// OptionError is the error of an option set with a value it does not take
+type OptionError struct {
+	Option int
+	Value  interface{}
+}
+
+func (e *OptionError) Error() string {
+	name, ok := optionNames[e.Option]
+	if !ok {
+		name = "option " + strconv.Itoa(e.Option)
+	}
+	return fmt.Sprintf("curl: %s does not take %T %v", name, e.Value, e.Value)
+}
+
+// Unwrap is E_BAD_FUNCTION_ARGUMENT, what libcurl returns for a bad value
+func (e *OptionError) Unwrap() error {
+	return CurlError(E_BAD_FUNCTION_ARGUMENT)
+}
+
+// SetWriteFunction sets OPT_WRITEFUNCTION, returning false pauses the transfer
+func (curl *CURL) SetWriteFunction(fun func([]byte, interface{}) bool) error {
+	curl.writeFunction = &fun
+	return curl.setoptCallback(OPT_WRITEFUNCTION, OPT_WRITEDATA, C.return_write_function())
+}
+
+// SetHeaderFunction sets OPT_HEADERFUNCTION, returning false pauses the transfer
+func (curl *CURL) SetHeaderFunction(fun func([]byte, interface{}) bool) error {
+	curl.headerFunction = &fun
+	return curl.setoptCallback(OPT_HEADERFUNCTION, OPT_HEADERDATA, C.return_header_function())
+}
+
+// SetReadFunction sets OPT_READFUNCTION, fun returns the number of bytes read
+// or one of READFUNC_ABORT and READFUNC_PAUSE
+func (curl *CURL) SetReadFunction(fun func([]byte, interface{}) int) error {
+	curl.readFunction = &fun
+	return curl.setoptCallback(OPT_READFUNCTION, OPT_READDATA, C.return_read_function())
+}
+
+// SetSeekFunction sets OPT_SEEKFUNCTION, fun returns one of SEEKFUNC_*
+func (curl *CURL) SetSeekFunction(fun func(int64, int, interface{}) int) error {
+	curl.seekFunction = &fun
+	return curl.setoptCallback(OPT_SEEKFUNCTION, OPT_SEEKDATA, C.return_seek_function())
+}
+
+// SetProgressFunction sets OPT_PROGRESSFUNCTION, returning false aborts the transfer
+func (curl *CURL) SetProgressFunction(fun func(float64, float64, float64, float64, interface{}) bool) error {
+	curl.progressFunction = &fun
+	return curl.setoptCallback(OPT_PROGRESSFUNCTION, OPT_PROGRESSDATA, C.return_progress_function())
+}
+
+// DebugType is the kind of data passed to the OPT_DEBUGFUNCTION callback, one of DEBUG_*
+type DebugType int
+
+var debugTypeNames = map[DebugType]string{
+	DEBUG_TEXT:         "TEXT",
+	DEBUG_HEADER_IN:    "HEADER_IN",
+	DEBUG_HEADER_OUT:   "HEADER_OUT",
+	DEBUG_DATA_IN:      "DATA_IN",
+	DEBUG_DATA_OUT:     "DATA_OUT",
+	DEBUG_SSL_DATA_IN:  "SSL_DATA_IN",
+	DEBUG_SSL_DATA_OUT: "SSL_DATA_OUT",
+}
+
+func (t DebugType) String() string {
+	if name, ok := debugTypeNames[t]; ok {
+		return name
+	}
+	return "DebugType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// SetXferinfoFunction sets OPT_XFERINFOFUNCTION, it gets the total and the
+// transferred bytes of the download and the upload, 0 when unknown. A non
+// nil error aborts the transfer, see XferinfoError. libcurl only calls it
+// when OPT_NOPROGRESS is 0.
+func (curl *CURL) SetXferinfoFunction(fun func(dltotal, dlnow, ultotal, ulnow int64, userdata interface{}) error) error {
+	curl.xferinfoFunction = &fun
+	curl.xferinfoErr = nil
+	return curl.setoptCallback(OPT_XFERINFOFUNCTION, OPT_XFERINFODATA, C.return_xferinfo_function())
+}
+
+// SetDebugFunction sets OPT_DEBUGFUNCTION, libcurl only calls it with OPT_VERBOSE
+func (curl *CURL) SetDebugFunction(fun func(DebugType, []byte, interface{})) error {
+	curl.debugFunction = &fun
+	return curl.setoptCallback(OPT_DEBUGFUNCTION, OPT_DEBUGDATA, C.return_debug_function())
+}
+
+// SetHTTPPost sets OPT_HTTPPOST
+func (curl *CURL) SetHTTPPost(form *Form) error {
+	return curl.setoptPointer(OPT_HTTPPOST, unsafe.Pointer(form.head))
+}
+
+func (curl *CURL) setoptBool(opt int, enable bool) error {
+	if enable {
+		return curl.setoptLong(opt, 1)
+	}
+	return curl.setoptLong(opt, 0)
+}
+
+// integerValue converts the integers and bools Setopt takes for a long or an off_t
+func integerValue(param interface{}) (int64, bool) {
+	switch t := param.(type) {
+	case bool:
+		if t {
+			return 1, true
+		}
+		return 0, true
+	case int:
+		return int64(t), true
+	case int8:
+		return int64(t), true
+	case int16:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case int64:
+		return t, true
+	case uint:
+		return int64(t), uint64(t) <= math.MaxInt64
+	case uint8:
+		return int64(t), true
+	case uint16:
+		return int64(t), true
+	case uint32:
+		return int64(t), true
+	case uint64:
+		return int64(t), t <= math.MaxInt64
+	}
+	return 0, false
+}
+
+// durationMilliseconds rounds a positive d up to whole milliseconds, so it
+// does not become 0 which often means no limit
+func durationMilliseconds(d time.Duration) int64 {
+	if d > 0 {
+		return int64((d + time.Millisecond - 1) / time.Millisecond)
+	}
+	return int64(d / time.Millisecond)
+}
+
+// durationSeconds rounds a positive d up to whole seconds
+func durationSeconds(d time.Duration) int64 {
+	if d > 0 {
+		return int64((d + time.Second - 1) / time.Second)
+	}
+	return int64(d / time.Second)
+}
@@ -58,5 +58,4 @@ func (shcurl *CURLSH) Setopt(opt int, param interface{}) error {
 		}
 	}
 	panic("not supported CURLSH.Setopt opt or param")
-	return nil
 }
@@ -0,0 +1,145 @@
+package libcurl
+
+/*
+#include <stdint.h>
+#include "./include/curl.h"
+
+// from openssl/ssl.h, boringssl and libressl share these
+typedef struct ssl_st SSL;
+typedef struct ssl_cipher_st SSL_CIPHER;
+int SSL_version(const SSL *ssl);
+const SSL_CIPHER *SSL_get_current_cipher(const SSL *ssl);
+uint32_t SSL_CIPHER_get_id(const SSL_CIPHER *cipher);
+void SSL_get0_alpn_selected(const SSL *ssl, const unsigned char **data, unsigned int *len);
+
+static int curl_easy_ssl_info(CURL *curl, int *version, uint32_t *cipher, const unsigned char **alpn, unsigned int *alpn_len) {
+  struct curl_tlssessioninfo *info = NULL;
+  const SSL_CIPHER *current;
+  SSL *ssl;
+  CURLcode ret = curl_easy_getinfo(curl, CURLINFO_TLS_SSL_PTR, &info);
+  if(ret != CURLE_OK)
+    return ret;
+  if(!info || info->backend != CURLSSLBACKEND_OPENSSL || !info->internals)
+    return -1;
+
+  ssl = (SSL *)info->internals;
+  *version = SSL_version(ssl);
+  current = SSL_get_current_cipher(ssl);
+  *cipher = current ? SSL_CIPHER_get_id(current) : 0;
+  SSL_get0_alpn_selected(ssl, alpn, alpn_len);
+  return CURLE_OK;
+}
+*/
+import "C"
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"unsafe"
+)
+
+// TLSSessionInfo is the session of INFO_TLS_SSL_PTR and INFO_TLS_SESSION,
+// Internals is the SSL * of OpenSSL and its forks, the context of the other
+// backends. It is owned by libcurl and only valid while the transfer runs.
+type TLSSessionInfo struct {
+	Backend   int // one of the SSLBACKEND_* constants
+	Internals unsafe.Pointer
+}
+
+// TLSSession gets INFO_TLS_SSL_PTR, nil when the transfer does not use TLS
+func (curl *CURL) TLSSession() (*TLSSessionInfo, error) {
+	return curl.getinfoTLSSession(INFO_TLS_SSL_PTR)
+}
+
+// TLSInfo is the negotiated state of the TLS connection of a transfer
+type TLSInfo struct {
+	Version     uint16 // same as tls.VersionTLS12 and friends
+	CipherSuite uint16 // IANA id, same as tls.TLS_* constants
+	ALPN        string
+}
+
+// TLSInfo reads the session of CURLINFO_TLS_SSL_PTR, it is only valid while the
+// transfer runs, e.g. in a callback. nil is returned when the connection does
+// not use TLS or the TLS backend is not OpenSSL based.
+func (curl *CURL) TLSInfo() (*TLSInfo, error) {
+	p := curl.handle
+	version := C.int(0)
+	cipher := C.uint32_t(0)
+	var alpn *C.uchar
+	alpnLen := C.uint(0)
+	ret := C.curl_easy_ssl_info(p, &version, &cipher, &alpn, &alpnLen)
+	if ret < 0 {
+		return nil, nil
+	}
+	if err := newCurlError(C.CURLcode(ret)); err != nil {
+		return nil, err
+	}
+
+	info := &TLSInfo{
+		Version: uint16(version),
+		// openssl prefixes the IANA id with 0x0300
+		CipherSuite: uint16(cipher & 0xffff),
+	}
+	if alpn != nil {
+		info.ALPN = C.GoStringN((*C.char)(unsafe.Pointer(alpn)), C.int(alpnLen))
+	}
+	return info, nil
+}
+
+// SetClientCertificate sets the chain and the private key of cert as PEM
+// blobs, the client certificate of the TLS connection to the server.
+func (curl *CURL) SetClientCertificate(cert *tls.Certificate) error {
+	return curl.setCertificate(cert, OPT_SSLCERTTYPE, OPT_SSLCERT_BLOB, OPT_SSLKEYTYPE, OPT_SSLKEY_BLOB)
+}
+
+// SetProxyClientCertificate is SetClientCertificate for the TLS connection to a https proxy
+func (curl *CURL) SetProxyClientCertificate(cert *tls.Certificate) error {
+	return curl.setCertificate(cert, OPT_PROXY_SSLCERTTYPE, OPT_PROXY_SSLCERT_BLOB, OPT_PROXY_SSLKEYTYPE, OPT_PROXY_SSLKEY_BLOB)
+}
+
+func (curl *CURL) setCertificate(cert *tls.Certificate, certType, certBlob, keyType, keyBlob int) (err error) {
+	certPEM, keyPEM, err := CertificatePEM(cert)
+	if err != nil {
+		return
+	}
+
+	err = curl.setoptString(certType, "PEM")
+	if err != nil {
+		return
+	}
+
+	err = curl.setoptBlob(certBlob, certPEM)
+	if err != nil {
+		return
+	}
+
+	err = curl.setoptString(keyType, "PEM")
+	if err != nil {
+		return
+	}
+
+	return curl.setoptBlob(keyBlob, keyPEM)
+}
+
+// CertificatePEM returns the PEM encoded chain and PKCS #8 private key of cert
+func CertificatePEM(cert *tls.Certificate) (certPEM, keyPEM []byte, err error) {
+	if len(cert.Certificate) == 0 {
+		return nil, nil, errors.New("curl: client certificate is empty")
+	}
+
+	certBuffer := new(bytes.Buffer)
+	for _, der := range cert.Certificate {
+		if err = pem.Encode(certBuffer, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			return
+		}
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return
+	}
+	return certBuffer.Bytes(), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}